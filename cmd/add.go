@@ -0,0 +1,116 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/spf13/cobra"
+
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add <task>",
+	Short: "Add a task to today's daily note, a project or a file without opening the TUI",
+	Long: `Add a task using the same syntax as the task editor e.g. task add "Buy milk due:2024-12-01 p:2".
+Dates can also be relative to today e.g. due:tomorrow, due:fri, due:+3d, due:next-month or sched:eow.
+
+By default the task is appended to today's daily note (created if it does not exist yet).
+Use --project or --file to append it somewhere else instead.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  add,
+}
+
+func init() {
+	addCmd.Flags().String("project", "", "name of the project to add the task to")
+	addCmd.Flags().String("file", "", "path of the file to add the task to, relative to the workspace root")
+	addCmd.Flags().String("status", "todo", "status of the new task (todo, doing, blocked, done, abandoned)")
+	addCmd.MarkFlagsMutuallyExclusive("project", "file")
+	rootCmd.AddCommand(addCmd)
+}
+
+func add(cmd *cobra.Command, args []string) {
+	cfg := loadConfig()
+
+	closeLog := configureLogging(cfg)
+	defer closeLog()
+
+	statusFlag, _ := cmd.Flags().GetString("status")
+	status, err := parseStatus(statusFlag)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	task, err := parseTaskText(strings.Join(args, " "), status, cfg.now(), cfg.weekStart, cfg.calendar)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client := newClient(cfg)
+
+	path, err := addTarget(cmd, cfg, client)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	opts := notedown.TaskOptions(task)
+	if status == tasks.Done && task.Completed() == nil {
+		opts = append(opts, tasks.WithCompleted(cfg.now()))
+	}
+	created := tasks.NewTask(tasks.NewIdentifier(path, "", writer.AT_END), task.Name(), status, opts...)
+
+	after := lastMatchingLine(client, path, created.Body())
+	if err := client.CreateTask(path, writer.AT_END, created.Name(), created.Status(), opts...); err != nil {
+		fmt.Println("error creating task:", err)
+		os.Exit(1)
+	}
+
+	written, err := waitForTask(client, created, after, 2*time.Second)
+	if err != nil {
+		// The task has been written, we just don't know which line it ended up on
+		fmt.Printf("%s %s\n", path, created)
+		return
+	}
+	fmt.Printf("%s %s\n", location(written), written)
+}
+
+// addTarget works out which document a new task should be appended to from the flags.
+func addTarget(cmd *cobra.Command, cfg config, nd notedown.Client) (string, error) {
+	if file, _ := cmd.Flags().GetString("file"); file != "" {
+		return file, nil
+	}
+	if name, _ := cmd.Flags().GetString("project"); name != "" {
+		project, err := findProject(nd, name)
+		if err != nil {
+			return "", err
+		}
+		return project.Path(), nil
+	}
+	now := cfg.now()
+	d, _, err := nd.EnsureDaily(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), time.Second*2)
+	if err != nil {
+		return "", fmt.Errorf("error ensuring daily note: %w", err)
+	}
+	return d.Path(), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+
+	"github.com/notedownorg/task/pkg/views/agenda"
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Print the agenda for a day without opening the TUI",
+	Long: `Print the agenda for a day, the same tasks that the agenda view shows (doing, todo and blocked tasks
+that are due or scheduled plus the tasks that were completed on the day).
+
+Use the global --date flag to print the agenda for a day other than today.`,
+	Args: cobra.NoArgs,
+	Run:  printAgenda,
+}
+
+func init() {
+	agendaCmd.Flags().Bool("no-color", false, "disable colors and styling, useful when piping the output elsewhere")
+	agendaCmd.Flags().Int("width", 80, "width of the printed agenda")
+	rootCmd.AddCommand(agendaCmd)
+}
+
+func printAgenda(cmd *cobra.Command, args []string) {
+	cfg := loadConfig()
+
+	closeLog := configureLogging(cfg)
+	defer closeLog()
+
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	width, _ := cmd.Flags().GetInt("width")
+
+	client := newClient(cfg)
+
+	now := cfg.now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	fmt.Println(agenda.Render(client, cfg.theme, date, now, width))
+}
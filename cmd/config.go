@@ -0,0 +1,298 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/notedownorg/task/pkg/dates"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/themes"
+	"github.com/notedownorg/task/pkg/views/confirm"
+)
+
+// config is the effective configuration after merging the config file, environment variables and flags.
+// The exported fields map directly to the keys of the config file.
+type config struct {
+	Workspace string    `mapstructure:"workspace" yaml:"workspace"`
+	Theme     string    `mapstructure:"theme" yaml:"theme"`
+	View      string    `mapstructure:"view" yaml:"view"`
+	WeekStart string    `mapstructure:"week_start" yaml:"week_start"`
+	Log       logConfig `mapstructure:"log" yaml:"log"`
+
+	// WorkWeek lists the days that are worked, the rest are shown as weekends.
+	WorkWeek []string `mapstructure:"work_week" yaml:"work_week"`
+	// WorkingDays makes rescheduling and relative dates count working days only, skipping weekends and holidays.
+	WorkingDays bool `mapstructure:"working_days" yaml:"working_days"`
+	// Holidays is an .ics or plain date file listing days off, relative to the workspace unless absolute.
+	Holidays string `mapstructure:"holidays" yaml:"holidays"`
+
+	// Recurrence configures the next occurrence added when a recurring task is completed.
+	Recurrence recurrenceConfig `mapstructure:"recurrence" yaml:"recurrence"`
+
+	// Confirm lists the destructive actions that open a confirmation dialog before they run e.g. delete_task.
+	Confirm []string `mapstructure:"confirm" yaml:"confirm"`
+
+	// Keys holds per-view key binding overrides, keyed by view and then by action e.g. keys.agenda.CompleteTask.
+	// The filter and selection keys shared by the list views are under keys.list e.g. keys.list.Mark.
+	// Note that keys in the config file are case-insensitive.
+	Keys map[string]map[string][]string `mapstructure:"keys" yaml:"keys"`
+
+	// Resolved values
+	home       string
+	date       *time.Time
+	theme      themes.Theme
+	weekStart  time.Weekday
+	calendar   dates.Calendar
+	recurrence notedown.Recurrence
+	logLevel   slog.Level
+}
+
+type recurrenceConfig struct {
+	// From is the date the next occurrence is counted from, either due or completion.
+	From string `mapstructure:"from" yaml:"from"`
+	// Into is where the next occurrence is added, either same_file or daily_note.
+	Into string `mapstructure:"into" yaml:"into"`
+}
+
+type logConfig struct {
+	Level string `mapstructure:"level" yaml:"level"`
+	Path  string `mapstructure:"path" yaml:"path"`
+}
+
+// now returns the pinned date if one has been set, otherwise the current time.
+func (c config) now() time.Time {
+	if c.date != nil {
+		return *c.date
+	}
+	return time.Now()
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration after merging the config file, environment variables and flags",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfig()
+
+		if configFileLoaded {
+			fmt.Printf("# loaded from %s\n", viper.ConfigFileUsed())
+		} else {
+			fmt.Printf("# no config file found, expected at %s\n", viper.ConfigFileUsed())
+		}
+
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(cfg); err != nil {
+			fmt.Println("error printing config:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func loadConfig() config {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Println("error getting user home directory:", err)
+		os.Exit(1)
+	}
+
+	cfg := config{home: home}
+	if err := viper.Unmarshal(&cfg); err != nil {
+		fmt.Println("error reading config:", err)
+		os.Exit(1)
+	}
+
+	if cfg.Workspace == "" {
+		fmt.Println("Please set NOTEDOWN_DIR environment variable or workspace in the config file to the root of your Notedown workspace")
+		os.Exit(1)
+	}
+
+	theme, ok := themes.Themes[cfg.Theme]
+	if !ok {
+		fmt.Printf("unknown theme %q, expected one of %s\n", cfg.Theme, strings.Join(keys(themes.Themes), ", "))
+		os.Exit(1)
+	}
+	cfg.theme = theme
+
+	if _, ok := initialViews[cfg.View]; !ok {
+		fmt.Printf("unknown view %q, expected one of %s\n", cfg.View, strings.Join(keys(initialViews), ", "))
+		os.Exit(1)
+	}
+
+	weekStart, err := parseWeekday("week_start", cfg.WeekStart)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	cfg.weekStart = weekStart
+
+	workWeek := make([]time.Weekday, 0, len(cfg.WorkWeek))
+	for _, name := range cfg.WorkWeek {
+		day, err := parseWeekday("work_week", name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		workWeek = append(workWeek, day)
+	}
+	var holidays []time.Time
+	if cfg.Holidays != "" {
+		file := cfg.Holidays
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(cfg.Workspace, file)
+		}
+		if holidays, err = dates.LoadHolidays(file); err != nil {
+			fmt.Println("error reading holidays:", err)
+			os.Exit(1)
+		}
+	}
+	cfg.calendar = dates.NewCalendar(cfg.WorkingDays, workWeek, holidays)
+
+	switch cfg.Recurrence.From {
+	case "due", "completion":
+		cfg.recurrence.FromCompletion = cfg.Recurrence.From == "completion"
+	default:
+		fmt.Printf("invalid recurrence.from %q, expected due or completion\n", cfg.Recurrence.From)
+		os.Exit(1)
+	}
+	switch cfg.Recurrence.Into {
+	case "same_file", "daily_note":
+		cfg.recurrence.DailyNote = cfg.Recurrence.Into == "daily_note"
+	default:
+		fmt.Printf("invalid recurrence.into %q, expected same_file or daily_note\n", cfg.Recurrence.Into)
+		os.Exit(1)
+	}
+
+	for _, action := range cfg.Confirm {
+		if !slices.Contains(confirm.Actions, action) {
+			fmt.Printf("unknown confirm action %q, expected one of %s\n", action, strings.Join(confirm.Actions, ", "))
+			os.Exit(1)
+		}
+	}
+
+	if err := cfg.logLevel.UnmarshalText([]byte(cfg.Log.Level)); err != nil {
+		fmt.Printf("invalid log level %q, expected one of debug, info, warn or error\n", cfg.Log.Level)
+		os.Exit(1)
+	}
+	if cfg.Log.Path == "" {
+		cfg.Log.Path = filepath.Join(home, ".notedown", "logs", "task.log")
+	}
+
+	// Time should almost always be now, but it can be pinned with the --date flag (or TEST_DATE for the feature tests)
+	if t := viper.GetString("date"); t != "" {
+		tt, err := time.Parse("2006-01-02", t)
+		if err != nil {
+			fmt.Printf("invalid date %q, expected a date in the form 2006-01-02\n", t)
+			os.Exit(1)
+		}
+		tt = tt.UTC()
+		cfg.date = &tt
+	}
+
+	return cfg
+}
+
+// configFileLoaded records whether a config file was found and read by initConfig.
+var configFileLoaded bool
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig() {
+	viper.SetDefault("theme", "catppuccin-mocha")
+	viper.SetDefault("view", "agenda")
+	viper.SetDefault("week_start", "monday")
+	viper.SetDefault("log.level", "debug")
+	viper.SetDefault("log.path", "")
+	viper.SetDefault("work_week", []string{"monday", "tuesday", "wednesday", "thursday", "friday"})
+	viper.SetDefault("working_days", false)
+	viper.SetDefault("holidays", "")
+	viper.SetDefault("recurrence.from", "completion")
+	viper.SetDefault("recurrence.into", "same_file")
+	viper.SetDefault("confirm", confirm.Actions)
+	viper.SetDefault("keys", map[string]map[string][]string{})
+
+	viper.SetEnvPrefix("notedown")
+	viper.BindEnv("workspace", "NOTEDOWN_DIR")
+	viper.BindEnv("date", "TEST_DATE")
+	viper.AutomaticEnv() // read in environment variables that match
+
+	if file, _ := rootCmd.PersistentFlags().GetString("config"); file != "" {
+		viper.SetConfigFile(file)
+	} else {
+		viper.SetConfigFile(defaultConfigFile())
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		// A missing config file is fine unless it was explicitly asked for
+		var notFound viper.ConfigFileNotFoundError
+		explicit, _ := rootCmd.PersistentFlags().GetString("config")
+		if errors.As(err, &notFound) || (explicit == "" && errors.Is(err, os.ErrNotExist)) {
+			return
+		}
+		fmt.Println("error reading config file:", err)
+		os.Exit(1)
+	}
+	configFileLoaded = true
+}
+
+// defaultConfigFile follows the XDG base directory spec, falling back to ~/.config when XDG_CONFIG_HOME is unset.
+func defaultConfigFile() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "notedown", "task.yaml")
+}
+
+func parseWeekday(key string, s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) || strings.EqualFold(d.String()[:3], s) {
+			return d, nil
+		}
+	}
+	return time.Monday, fmt.Errorf("invalid %s %q, expected a day of the week e.g. monday", key, s)
+}
+
+func keys[T any](m map[string]T) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/views/agenda"
+	"github.com/notedownorg/task/pkg/views/calendar"
+	"github.com/notedownorg/task/pkg/views/confirm"
+	"github.com/notedownorg/task/pkg/views/kanban"
+	"github.com/notedownorg/task/pkg/views/palette"
+	"github.com/notedownorg/task/pkg/views/projectadd"
+	"github.com/notedownorg/task/pkg/views/projectlist"
+	"github.com/notedownorg/task/pkg/views/projectmanager"
+	"github.com/notedownorg/task/pkg/views/taskeditor"
+	"github.com/notedownorg/task/pkg/views/taskmove"
+	"github.com/notedownorg/task/pkg/views/taskreschedule"
+	"github.com/notedownorg/task/pkg/views/weekview"
+)
+
+// keyMaps are the default key maps of every view, these are what the keys section of the config file remaps.
+var keyMaps = context.KeyMaps{
+	context.GlobalView: context.DefaultGlobalKeyMap.Bindings(),
+	"agenda":           agenda.DefaultKeyMap.Bindings(),
+	"calendar":         calendar.DefaultKeyMap.Bindings(),
+	"taskeditor":       taskeditor.DefaultKeyMap.Bindings(),
+	"taskreschedule":   taskreschedule.DefaultKeyMap.Bindings(),
+	"taskmove":         taskmove.DefaultKeyMap.Bindings(),
+	"projectlist":      projectlist.DefaultKeyMap.Bindings(),
+	"projectmanager":   projectmanager.DefaultKeyMap.Bindings(),
+	"projectadd":       projectadd.DefaultKeyMap.Bindings(),
+	"palette":          palette.DefaultKeyMap.Bindings(),
+	"kanban":           kanban.DefaultKeyMap.Bindings(),
+	"weekview":         weekview.DefaultKeyMap.Bindings(),
+	"confirm":          confirm.DefaultKeyMap.Bindings(),
+	"list":             groupedlist.DefaultKeyMap.Bindings(),
+}
+
+// components are the key maps shared by several views, the list handles its keys before the views that embed it.
+var components = map[string]context.Component{
+	"list": {
+		Views: []string{"agenda", "projectlist", "projectmanager", "kanban"},
+		Modal: groupedlist.ModalActions,
+	},
+}
+
+// configureKeys applies the key overrides from the config to the default key maps and checks for conflicts.
+// This must run before any views are created as the views copy the default key maps when they are built.
+func configureKeys(cfg config) error {
+	if err := keyMaps.Override(cfg.Keys); err != nil {
+		return err
+	}
+	if conflicts := keyMaps.Conflicts(components); len(conflicts) > 0 {
+		return fmt.Errorf("conflicting key bindings:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/views/agenda"
+)
+
+func TestDefaultKeyMapsHaveNoConflicts(t *testing.T) {
+	if conflicts := keyMaps.Conflicts(components); len(conflicts) > 0 {
+		t.Errorf("default key maps have conflicts: %v", conflicts)
+	}
+}
+
+func TestKeyMapsOverrideHelp(t *testing.T) {
+	view := agenda.DefaultKeyMap
+	km := context.KeyMaps{"agenda": view.Bindings()}
+	if err := km.Override(map[string]map[string][]string{"agenda": {"completetask": {"x", "space"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := view.CompleteTask.Help().Key; got != "x/space" {
+		t.Errorf("help key = %q, want %q", got, "x/space")
+	}
+	if got := agenda.DefaultKeyMap.CompleteTask.Help().Key; got != "x" {
+		t.Errorf("default key map was modified, help key = %q", got)
+	}
+}
+
+func TestKeyMapsOverride(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]map[string][]string
+		wantErr   bool
+		conflicts int
+	}{
+		{
+			name:      "remap action",
+			overrides: map[string]map[string][]string{"agenda": {"completetask": {"x", "ctrl+x"}}},
+		},
+		{
+			name:      "conflict with list",
+			overrides: map[string]map[string][]string{"agenda": {"completetask": {"x", "space"}}},
+			conflicts: 1,
+		},
+		{
+			name:      "remap list action",
+			overrides: map[string]map[string][]string{"list": {"mark": {"m"}}},
+		},
+		{
+			name:      "list action conflicts with view",
+			overrides: map[string]map[string][]string{"list": {"markrange": {"x"}}},
+			conflicts: 1,
+		},
+		{
+			name:      "modal list action takes precedence",
+			overrides: map[string]map[string][]string{"list": {"clearmarks": {"d"}}},
+		},
+		{
+			name:      "unknown view",
+			overrides: map[string]map[string][]string{"nope": {"completetask": {"x"}}},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown action",
+			overrides: map[string]map[string][]string{"agenda": {"nope": {"x"}}},
+			wantErr:   true,
+		},
+		{
+			name:      "conflict within view",
+			overrides: map[string]map[string][]string{"agenda": {"deletetask": {"x"}}},
+			conflicts: 1,
+		},
+		{
+			name:      "conflict with global",
+			overrides: map[string]map[string][]string{"global": {"quit": {"x"}}},
+			conflicts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			global, view, list := context.DefaultGlobalKeyMap, agenda.DefaultKeyMap, groupedlist.DefaultKeyMap
+			km := context.KeyMaps{
+				context.GlobalView: global.Bindings(),
+				"agenda":           view.Bindings(),
+				"list":             list.Bindings(),
+			}
+			components := map[string]context.Component{
+				"list": {Views: []string{"agenda"}, Modal: groupedlist.ModalActions},
+			}
+
+			err := km.Override(tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Override() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(km.Conflicts(components)); got != tt.conflicts {
+				t.Errorf("Conflicts() = %v, want %d", km.Conflicts(components), tt.conflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/pkg/collections"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/spf13/cobra"
+
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks matching the given filters",
+	Long: `List tasks matching the given filters in a table, as JSON or as markdown.
+
+Date filters are inclusive and take dates in the form 2006-01-02.
+Tasks are sorted by status (doing, todo, blocked, done, abandoned) and then by priority, the same as the agenda.`,
+	Args: cobra.NoArgs,
+	Run:  list,
+}
+
+func init() {
+	listFlags(listCmd)
+	rootCmd.AddCommand(listCmd)
+}
+
+func listFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("status", []string{"todo", "doing", "blocked"}, "only include tasks with one of these statuses")
+	cmd.Flags().String("due-before", "", "only include tasks due on or before this date")
+	cmd.Flags().String("due-after", "", "only include tasks due on or after this date")
+	cmd.Flags().String("scheduled-before", "", "only include tasks scheduled on or before this date")
+	cmd.Flags().String("scheduled-after", "", "only include tasks scheduled on or after this date")
+	cmd.Flags().IntSlice("priority", nil, "only include tasks with one of these priorities")
+	cmd.Flags().String("project", "", "only include tasks from this project")
+	cmd.Flags().String("file", "", "only include tasks from this file, relative to the workspace root")
+	cmd.Flags().String("format", formatTable, "output format (table, json, md)")
+	cmd.MarkFlagsMutuallyExclusive("project", "file")
+}
+
+func list(cmd *cobra.Command, args []string) {
+	cfg := loadConfig()
+
+	closeLog := configureLogging(cfg)
+	defer closeLog()
+
+	format, _ := cmd.Flags().GetString("format")
+	if err := validateFormat(format); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	filter, err := listFilter(cmd)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client := newClient(cfg)
+
+	res, err := listTasks(cmd, client, filter)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := printTasks(os.Stdout, format, res); err != nil {
+		fmt.Println("error printing tasks:", err)
+		os.Exit(1)
+	}
+}
+
+// listTasks returns the tasks of the document selected by the flags that match the filter, in agenda order.
+func listTasks(cmd *cobra.Command, nd notedown.Client, filter collections.Filter[tasks.Task]) ([]tasks.Task, error) {
+	fetcher, err := listFetcher(cmd, nd)
+	if err != nil {
+		return nil, err
+	}
+	return nd.ListTasks(
+		fetcher,
+		tasks.WithFilter(filter),
+		tasks.WithSorters(
+			tasks.SortByStatus(tasks.AgendaOrder()),
+			tasks.SortByPriority(),
+		),
+	), nil
+}
+
+func listFetcher(cmd *cobra.Command, nd notedown.Client) (tasks.Fetcher, error) {
+	if file, _ := cmd.Flags().GetString("file"); file != "" {
+		return tasks.FetchTasksForDocument(file), nil
+	}
+	if name, _ := cmd.Flags().GetString("project"); name != "" {
+		project, err := findProject(nd, name)
+		if err != nil {
+			return nil, err
+		}
+		return tasks.FetchTasksForDocument(project.Path()), nil
+	}
+	return tasks.FetchAllTasks(), nil
+}
+
+// listFilter builds a single filter from the flags, each flag that is set is AND'd together.
+func listFilter(cmd *cobra.Command) (collections.Filter[tasks.Task], error) {
+	filters := make([]collections.Filter[tasks.Task], 0)
+
+	names, _ := cmd.Flags().GetStringSlice("status")
+	statuses := make([]tasks.Status, 0, len(names))
+	for _, name := range names {
+		status, err := parseStatus(name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	if len(statuses) > 0 {
+		filters = append(filters, tasks.FilterByStatus(statuses...))
+	}
+
+	dueAfter, dueBefore, err := dateRange(cmd, "due-after", "due-before")
+	if err != nil {
+		return nil, err
+	}
+	if dueAfter != nil || dueBefore != nil {
+		filters = append(filters, tasks.FilterByDueDate(dueAfter, dueBefore))
+	}
+
+	scheduledAfter, scheduledBefore, err := dateRange(cmd, "scheduled-after", "scheduled-before")
+	if err != nil {
+		return nil, err
+	}
+	if scheduledAfter != nil || scheduledBefore != nil {
+		filters = append(filters, tasks.FilterByScheduledDate(scheduledAfter, scheduledBefore))
+	}
+
+	if priorities, _ := cmd.Flags().GetIntSlice("priority"); len(priorities) > 0 {
+		filters = append(filters, tasks.FilterByPriority(priorities...))
+	}
+
+	return tasks.And(filters...), nil
+}
+
+func dateRange(cmd *cobra.Command, afterFlag string, beforeFlag string) (*time.Time, *time.Time, error) {
+	parseFlag := func(flag string) (*time.Time, error) {
+		value, _ := cmd.Flags().GetString(flag)
+		if value == "" {
+			return nil, nil
+		}
+		t, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s %s, expected a date in the form 2006-01-02", flag, strconv.Quote(value))
+		}
+		return &t, nil
+	}
+	after, err := parseFlag(afterFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err := parseFlag(beforeFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return after, before, nil
+}
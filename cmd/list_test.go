@@ -0,0 +1,186 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const alphaProject = `---
+type: project
+name: Alpha
+status: active
+---
+# Alpha
+- [ ] Write spec due:2024-06-03 priority:1
+- [/] Review spec due:2024-06-10
+- [x] Plan spec due:2024-05-20 completed:2024-05-21
+`
+
+const notes = `# Notes
+- [ ] Buy milk due:2024-06-05 priority:2
+- [b] Fix bike scheduled:2024-06-07 every:week
+`
+
+func TestList(t *testing.T) {
+	nd, _ := testenv.Workspace(t, notedown.NewClient, map[string]string{"projects/Alpha.md": alphaProject, "notes.md": notes})
+
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "default statuses",
+			flags: map[string]string{"format": formatMarkdown},
+			want: `- [/] Review spec due:2024-06-10
+- [ ] Write spec due:2024-06-03 priority:1
+- [ ] Buy milk due:2024-06-05 priority:2
+- [b] Fix bike scheduled:2024-06-07 every:week
+`,
+		},
+		{
+			name:  "status",
+			flags: map[string]string{"status": "done,blocked", "format": formatMarkdown},
+			want: `- [b] Fix bike scheduled:2024-06-07 every:week
+- [x] Plan spec due:2024-05-20 completed:2024-05-21
+`,
+		},
+		{
+			name:  "project",
+			flags: map[string]string{"project": "alpha", "status": "todo", "format": formatMarkdown},
+			want: `- [ ] Write spec due:2024-06-03 priority:1
+`,
+		},
+		{
+			name:    "unknown project",
+			flags:   map[string]string{"project": "beta"},
+			wantErr: true,
+		},
+		{
+			name:  "file",
+			flags: map[string]string{"file": "notes.md", "format": formatMarkdown},
+			want: `- [ ] Buy milk due:2024-06-05 priority:2
+- [b] Fix bike scheduled:2024-06-07 every:week
+`,
+		},
+		{
+			name:  "due range is inclusive",
+			flags: map[string]string{"due-after": "2024-06-03", "due-before": "2024-06-05", "format": formatMarkdown},
+			want: `- [ ] Write spec due:2024-06-03 priority:1
+- [ ] Buy milk due:2024-06-05 priority:2
+`,
+		},
+		{
+			name:    "invalid due date",
+			flags:   map[string]string{"due-before": "next week"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown status",
+			flags:   map[string]string{"status": "later"},
+			wantErr: true,
+		},
+		{
+			name:  "scheduled",
+			flags: map[string]string{"scheduled-after": "2024-06-01", "format": formatMarkdown},
+			want: `- [b] Fix bike scheduled:2024-06-07 every:week
+`,
+		},
+		{
+			name:  "priority",
+			flags: map[string]string{"priority": "2", "format": formatMarkdown},
+			want: `- [ ] Buy milk due:2024-06-05 priority:2
+`,
+		},
+		{
+			name:  "table",
+			flags: map[string]string{"file": "notes.md", "format": formatTable},
+			want: `LOCATION    STATUS   DUE         SCHEDULED   PRIORITY  EVERY  NAME
+notes.md:2  todo     2024-06-05  -           2         -      Buy milk
+notes.md:3  blocked  -           2024-06-07  -         week   Fix bike
+`,
+		},
+		{
+			name:  "json",
+			flags: map[string]string{"file": "notes.md", "status": "blocked", "format": formatJSON},
+			want: `[
+  {
+    "path": "notes.md",
+    "line": 3,
+    "name": "Fix bike",
+    "status": "blocked",
+    "due": null,
+    "scheduled": "2024-06-07",
+    "completed": null,
+    "priority": null,
+    "every": "week"
+  }
+]
+`,
+		},
+		{
+			name:  "json without matches",
+			flags: map[string]string{"status": "abandoned", "format": formatJSON},
+			want:  "[]\n",
+		},
+		{
+			name:    "unknown format",
+			flags:   map[string]string{"format": "csv"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			listFlags(cmd)
+			for flag, value := range tt.flags {
+				if err := cmd.Flags().Set(flag, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var b strings.Builder
+			err := func() error {
+				format, _ := cmd.Flags().GetString("format")
+				if err := validateFormat(format); err != nil {
+					return err
+				}
+				filter, err := listFilter(cmd)
+				if err != nil {
+					return err
+				}
+				res, err := listTasks(cmd, nd, filter)
+				if err != nil {
+					return err
+				}
+				return printTasks(&b, format, res)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+)
+
+const (
+	formatTable    = "table"
+	formatJSON     = "json"
+	formatMarkdown = "md"
+)
+
+// jsonTask is the machine-readable representation of a task, the keys are always present so the shape is stable.
+type jsonTask struct {
+	Path      string  `json:"path"`
+	Line      int     `json:"line"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Due       *string `json:"due"`
+	Scheduled *string `json:"scheduled"`
+	Completed *string `json:"completed"`
+	Priority  *int    `json:"priority"`
+	Every     *string `json:"every"`
+}
+
+func newJSONTask(task tasks.Task) jsonTask {
+	date := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		s := t.Format("2006-01-02")
+		return &s
+	}
+	var every *string
+	if task.Every() != nil {
+		s := task.Every().String()
+		every = &s
+	}
+	return jsonTask{
+		Path:      task.Path(),
+		Line:      task.Line(),
+		Name:      task.Name(),
+		Status:    statusName(task.Status()),
+		Due:       date(task.Due()),
+		Scheduled: date(task.Scheduled()),
+		Completed: date(task.Completed()),
+		Priority:  task.Priority(),
+		Every:     every,
+	}
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatMarkdown:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, expected one of table, json or md", format)
+}
+
+func printTasks(w io.Writer, format string, tsks []tasks.Task) error {
+	switch format {
+	case formatJSON:
+		out := make([]jsonTask, 0, len(tsks))
+		for _, t := range tsks {
+			out = append(out, newJSONTask(t))
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+
+	case formatMarkdown:
+		for _, t := range tsks {
+			if _, err := fmt.Fprintln(w, t.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LOCATION\tSTATUS\tDUE\tSCHEDULED\tPRIORITY\tEVERY\tNAME")
+		for _, t := range tsks {
+			j := newJSONTask(t)
+			fmt.Fprintln(tw, strings.Join([]string{
+				location(t),
+				j.Status,
+				orDash(j.Due),
+				orDash(j.Scheduled),
+				orDash(priorityString(j.Priority)),
+				orDash(j.Every),
+				j.Name,
+			}, "\t"))
+		}
+		return tw.Flush()
+	}
+	return validateFormat(format)
+}
+
+func priorityString(p *int) *string {
+	if p == nil {
+		return nil
+	}
+	s := fmt.Sprintf("%d", *p)
+	return &s
+}
+
+func orDash(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
@@ -31,9 +31,14 @@ import (
 	"github.com/notedownorg/task/pkg/context"
 	"github.com/notedownorg/task/pkg/listeners"
 	"github.com/notedownorg/task/pkg/notedown"
-	"github.com/notedownorg/task/pkg/themes"
 	"github.com/notedownorg/task/pkg/views/agenda"
+	"github.com/notedownorg/task/pkg/views/calendar"
+	"github.com/notedownorg/task/pkg/views/keyhelp"
+	"github.com/notedownorg/task/pkg/views/palette"
+	"github.com/notedownorg/task/pkg/views/projectadd"
 	"github.com/notedownorg/task/pkg/views/projectlist"
+	"github.com/notedownorg/task/pkg/views/taskeditor"
+	"github.com/notedownorg/task/pkg/views/weekview"
 )
 
 var (
@@ -52,26 +57,17 @@ var rootCmd = &cobra.Command{
 func root(cmd *cobra.Command, args []string) {
 	cfg := loadConfig()
 
-	// Configure logging to a file
-	logFileLocation := path.Join(cfg.home, ".notedown", "logs", "task.log")
-	if err := os.MkdirAll(path.Dir(logFileLocation), 0755); err != nil {
-		fmt.Println("error creating log directory:", err)
-		os.Exit(1)
-	}
-	logFile, err := os.OpenFile(logFileLocation, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
-	if err != nil {
-		fmt.Println("error opening log file:", err)
-		os.Exit(1)
-	}
-	defer logFile.Close()
-	slog.SetDefault(slog.New(slog.NewTextHandler(logFile, &slog.HandlerOptions{Level: slog.LevelDebug, AddSource: true})))
+	closeLog := configureLogging(cfg)
+	defer closeLog()
 
-	client, err := notedown.NewClient(cfg.root)
-	if err != nil {
-		fmt.Println("error creating client:", err)
+	if err := configureKeys(cfg); err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	// Only the TUI can undo changes so only it needs the journal
+	client := notedown.NewJournal(newClient(cfg), cfg.Workspace)
+
 	// Create a listener for the clients that need to refresh the TUI when objects are created/updated/deleted
 	taskSub, projectSub := make(chan tasks.Event), make(chan projects.Event)
 	client.Subscribe(taskSub, projectSub)
@@ -80,20 +76,31 @@ func root(cmd *cobra.Command, args []string) {
 
 	opts := make([]context.ProgramContextOption, 0)
 	opts = append(opts, context.WithListeners(taskListener, projectListener))
+	opts = append(opts, context.WithWeekStart(cfg.weekStart))
+	opts = append(opts, context.WithCalendar(cfg.calendar))
+	opts = append(opts, context.WithConfirmations(cfg.Confirm...))
 	if cfg.date != nil {
 		opts = append(opts, context.WithClock(func() time.Time { return *cfg.date }))
 	}
 
 	// Create the initial model and run the program
 	ctx := context.New(
-		themes.CatpuccinMocha,
-		func(ctx *context.ProgramContext) tea.Model { return agenda.New(ctx, client) },
+		cfg.theme,
+		initialViews[cfg.View](client),
 		opts...,
 	).SetGlobalKeyHandlers(
 		context.HandleQuit(),
 		context.HandleBack(),
 		projectlist.HandleNew(client),
 		agenda.HandleNew(client),
+		weekview.HandleNew(client),
+		calendar.HandleNew(client),
+		taskeditor.HandleNew(client),
+		projectadd.HandleNew(client),
+		keyhelp.HandleOpen(client),
+		palette.HandleOpen(client),
+		context.HandleUndo(client),
+		context.HandleRedo(client),
 	)
 
 	p := tea.NewProgram(ctx, tea.WithAltScreen())
@@ -103,56 +110,61 @@ func root(cmd *cobra.Command, args []string) {
 	}
 }
 
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() {
-	err := rootCmd.Execute()
+// configureLogging points the default logger at the log file so nothing is written over the TUI or the
+// output of the headless commands. The returned function closes the log file.
+func configureLogging(cfg config) func() {
+	if err := os.MkdirAll(path.Dir(cfg.Log.Path), 0755); err != nil {
+		fmt.Println("error creating log directory:", err)
+		os.Exit(1)
+	}
+	logFile, err := os.OpenFile(cfg.Log.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
 	if err != nil {
+		fmt.Println("error opening log file:", err)
 		os.Exit(1)
 	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(logFile, &slog.HandlerOptions{Level: cfg.logLevel, AddSource: true})))
+	return func() { logFile.Close() }
 }
 
-func init() {
-	cobra.OnInitialize(initConfig)
-}
-
-type config struct {
-	home string
-	root string
-	date *time.Time
-}
-
-func loadConfig() config {
-	cfg := config{}
-	cfg.root = viper.GetString("dir")
-	if cfg.root == "" {
-		fmt.Println("Please set NOTEDOWN_DIR environment variable to the root of your Notedown workspace")
+func newClient(cfg config) notedown.Client {
+	client, err := notedown.NewClient(cfg.Workspace)
+	if err != nil {
+		fmt.Println("error creating client:", err)
 		os.Exit(1)
 	}
+	return notedown.NewRecurring(client, cfg.recurrence)
+}
 
-	// Time should always be now, but for testing purposes we allow it to be set with a hidden env var
-	if t := os.Getenv("TEST_DATE"); t != "" {
-		if tt, err := time.Parse("2006-01-02", t); err == nil {
-			tt = tt.UTC()
-			cfg.date = &tt
-		}
-	}
+// initialViews are the views that can be configured as the first view shown when the TUI starts.
+var initialViews = map[string]func(notedown.Client) context.InitalViewBuilder{
+	"agenda": func(nd notedown.Client) context.InitalViewBuilder {
+		return func(ctx *context.ProgramContext) tea.Model { return agenda.New(ctx, nd) }
+	},
+	"week": func(nd notedown.Client) context.InitalViewBuilder {
+		return func(ctx *context.ProgramContext) tea.Model { return weekview.New(ctx, nd) }
+	},
+	"calendar": func(nd notedown.Client) context.InitalViewBuilder {
+		return func(ctx *context.ProgramContext) tea.Model { return calendar.New(ctx, nd) }
+	},
+	"projects": func(nd notedown.Client) context.InitalViewBuilder {
+		return func(ctx *context.ProgramContext) tea.Model { return projectlist.New(ctx, nd) }
+	},
+}
 
-	home, err := os.UserHomeDir()
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
 	if err != nil {
-		fmt.Println("error getting user home directory:", err)
 		os.Exit(1)
 	}
-	cfg.home = home
-
-	return cfg
 }
 
-// initConfig reads in config file and ENV variables if set.
-func initConfig() {
-	viper.SetEnvPrefix("notedown")
-	viper.BindEnv("dir")
-	viper.AutomaticEnv() // read in environment variables that match
+func init() {
+	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().String("config", "", "config file (default is $XDG_CONFIG_HOME/notedown/task.yaml)")
+	rootCmd.PersistentFlags().String("date", "", "pin today's date (2006-01-02) instead of using the system clock")
+	viper.BindPFlag("date", rootCmd.PersistentFlags().Lookup("date"))
 }
 
 func version() string {
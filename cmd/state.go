@@ -0,0 +1,145 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/spf13/cobra"
+
+	"github.com/notedownorg/task/pkg/dates"
+	"github.com/notedownorg/task/pkg/views/taskreschedule"
+)
+
+const selectorHelp = `A task is selected either by its location (path:line, as printed by task list) or by text that
+matches the name of exactly one task. If the selector matches more than one task nothing is changed.`
+
+func init() {
+	rootCmd.AddCommand(
+		statusCommand("done", "Mark a task as done", tasks.Done),
+		statusCommand("start", "Mark a task as doing", tasks.Doing),
+		statusCommand("block", "Mark a task as blocked", tasks.Blocked),
+		statusCommand("abandon", "Mark a task as abandoned", tasks.Abandoned),
+		rescheduleCmd,
+		deleteCmd,
+	)
+}
+
+func statusCommand(use string, short string, status tasks.Status) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <selector>",
+		Short: short,
+		Long:  short + ".\n\n" + selectorHelp,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := loadConfig()
+
+			closeLog := configureLogging(cfg)
+			defer closeLog()
+
+			updateSelected(cfg, strings.Join(args, " "), func(task tasks.Task) (tasks.Task, error) {
+				return tasks.NewTaskFromTask(task, tasks.WithStatus(status, cfg.now())), nil
+			})
+		},
+	}
+}
+
+var rescheduleCmd = &cobra.Command{
+	Use:   "reschedule <selector> <date>",
+	Short: "Move the due and/or scheduled date of a task",
+	Long: `Move whichever of the due and scheduled dates are set on a task to the given date (2006-01-02).
+Dates can also be relative to today e.g. tomorrow, fri, +3d, next-month or eow.
+
+` + selectorHelp,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfig()
+
+		closeLog := configureLogging(cfg)
+		defer closeLog()
+
+		// The date is checked first so a typo in it isn't reported as a problem with the selector
+		selector, value := strings.Join(args[:len(args)-1], " "), args[len(args)-1]
+		date, ok := dates.Parse(value, cfg.now(), cfg.weekStart, cfg.calendar)
+		if !ok {
+			fmt.Printf("invalid date %q, expected a date in the form 2006-01-02 or one relative to today e.g. tomorrow\n", value)
+			os.Exit(1)
+		}
+
+		updateSelected(cfg, selector, func(task tasks.Task) (tasks.Task, error) {
+			if task.Due() == nil && task.Scheduled() == nil {
+				return tasks.Task{}, fmt.Errorf("task has no due or scheduled date to reschedule: %s %s", location(task), task)
+			}
+			return taskreschedule.Reschedule(task, date), nil
+		})
+	},
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <selector>",
+	Short: "Delete a task",
+	Long:  "Delete a task.\n\n" + selectorHelp,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfig()
+
+		closeLog := configureLogging(cfg)
+		defer closeLog()
+
+		client := newClient(cfg)
+		task, err := selectTask(client, strings.Join(args, " "))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if err := client.DeleteTask(task); err != nil {
+			fmt.Println("error deleting task:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s %s\n", location(task), task)
+	},
+}
+
+// updateSelected resolves the selector, applies the mutation and writes the result back, printing the task as written.
+func updateSelected(cfg config, selector string, mutate func(tasks.Task) (tasks.Task, error)) {
+	client := newClient(cfg)
+	task, err := selectTask(client, selector)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	updated, err := mutate(task)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := client.UpdateTask(updated); err != nil {
+		fmt.Println("error updating task:", err)
+		os.Exit(1)
+	}
+
+	written, err := waitForTask(client, updated, updated.Line()-1, 2*time.Second)
+	if err != nil {
+		// The task has been written, we just haven't seen it reloaded
+		fmt.Printf("%s %s\n", location(updated), updated)
+		return
+	}
+	fmt.Printf("%s %s\n", location(written), written)
+}
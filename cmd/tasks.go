@@ -0,0 +1,169 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/parse"
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/dates"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+var statusNames = map[string]tasks.Status{
+	"todo":      tasks.Todo,
+	"doing":     tasks.Doing,
+	"blocked":   tasks.Blocked,
+	"done":      tasks.Done,
+	"abandoned": tasks.Abandoned,
+}
+
+func statusName(status tasks.Status) string {
+	for name, s := range statusNames {
+		if s == status {
+			return name
+		}
+	}
+	return string(status)
+}
+
+func parseStatus(name string) (tasks.Status, error) {
+	status, ok := statusNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("unknown status %q, expected one of todo, doing, blocked, done or abandoned", name)
+	}
+	return status, nil
+}
+
+// parseTaskText runs the text through the same grammar as the task editor, including relative dates.
+func parseTaskText(text string, status tasks.Status, now time.Time, weekStart time.Weekday, cal dates.Calendar) (tasks.Task, error) {
+	parser := tasks.ParseTask("", "", now)
+	expanded := dates.Expand(text, now, weekStart, cal)
+	task, ok, err := parser.Parse(parse.NewInput(fmt.Sprintf("- [%s] %s", status, expanded)))
+	if err != nil {
+		return tasks.Task{}, fmt.Errorf("unable to parse task: %w", err)
+	}
+	if !ok || task.Name() == "" {
+		return tasks.Task{}, fmt.Errorf("unable to parse task %q", text)
+	}
+	return task, nil
+}
+
+func findProject(nd notedown.Client, name string) (projects.Project, error) {
+	matches := make([]projects.Project, 0)
+	for _, p := range nd.ListProjects(projects.FetchAllProjects()) {
+		if strings.EqualFold(p.Name(), name) {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return projects.Project{}, fmt.Errorf("no project named %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		paths := make([]string, 0, len(matches))
+		for _, p := range matches {
+			paths = append(paths, p.Path())
+		}
+		return projects.Project{}, fmt.Errorf("project name %q is ambiguous, matches: %s", name, strings.Join(paths, ", "))
+	}
+}
+
+// lastMatchingLine returns the line of the last task in the document with the given body, or 0 if there is none.
+func lastMatchingLine(nd notedown.Client, path string, body string) int {
+	line := 0
+	for _, t := range nd.ListTasks(tasks.FetchTasksForDocument(path)) {
+		if t.Body() == body && t.Line() > line {
+			line = t.Line()
+		}
+	}
+	return line
+}
+
+// waitForTask waits for a task that has just been written to a document to show up in the client so we can report
+// its line. Writes are picked up asynchronously from the file watcher, hence the polling.
+func waitForTask(nd notedown.Client, want tasks.Task, after int, wait time.Duration) (tasks.Task, error) {
+	start := time.Now()
+	for {
+		for _, t := range nd.ListTasks(tasks.FetchTasksForDocument(want.Path())) {
+			if t.String() == want.String() && t.Line() > after {
+				return t, nil
+			}
+		}
+		if time.Since(start) > wait {
+			return tasks.Task{}, fmt.Errorf("timed out waiting for task to appear in %s", want.Path())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func location(task tasks.Task) string {
+	return fmt.Sprintf("%s:%d", task.Path(), task.Line())
+}
+
+// selectTask resolves a selector to a single task. A selector is either the location of the task (path:line) or
+// text matching the name of exactly one task. Exact name matches (ignoring case) win over partial matches.
+func selectTask(nd notedown.Client, selector string) (tasks.Task, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return tasks.Task{}, fmt.Errorf("empty task selector")
+	}
+
+	if i := strings.LastIndex(selector, ":"); i > 0 {
+		if line, err := strconv.Atoi(selector[i+1:]); err == nil {
+			for _, t := range nd.ListTasks(tasks.FetchTasksForDocument(selector[:i])) {
+				if t.Line() == line {
+					return t, nil
+				}
+			}
+			return tasks.Task{}, fmt.Errorf("no task found at %s", selector)
+		}
+	}
+
+	all := nd.ListTasks(tasks.FetchAllTasks(), tasks.WithSorters())
+	exact, partial := make([]tasks.Task, 0), make([]tasks.Task, 0)
+	for _, t := range all {
+		switch {
+		case strings.EqualFold(t.Name(), selector):
+			exact = append(exact, t)
+		case strings.Contains(strings.ToLower(t.Name()), strings.ToLower(selector)):
+			partial = append(partial, t)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+
+	switch len(matches) {
+	case 0:
+		return tasks.Task{}, fmt.Errorf("no task matches %q", selector)
+	case 1:
+		return matches[0], nil
+	default:
+		var b strings.Builder
+		fmt.Fprintf(&b, "%q matches %d tasks, use path:line to select one of:", selector, len(matches))
+		for _, t := range matches {
+			fmt.Fprintf(&b, "\n  %s %s", location(t), t)
+		}
+		return tasks.Task{}, fmt.Errorf("%s", b.String())
+	}
+}
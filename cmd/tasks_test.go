@@ -0,0 +1,89 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+func TestSelectTask(t *testing.T) {
+	nd, _ := testenv.Workspace(t, notedown.NewClient, map[string]string{
+		"a.md": "# A\n- [ ] Write report\n- [ ] Write report draft\n- [ ] Call Sam\n",
+		"b.md": "- [ ] Call Sam back\n- [ ] Call Alex\n",
+	})
+
+	tests := []struct {
+		name     string
+		selector string
+		want     string // location of the selected task
+		wantErr  bool
+	}{
+		{name: "location", selector: "a.md:3", want: "a.md:3"},
+		{name: "location without a task", selector: "a.md:1", wantErr: true},
+		{name: "unknown file", selector: "c.md:1", wantErr: true},
+		{name: "exact match wins over partial", selector: "write REPORT", want: "a.md:2"},
+		{name: "partial", selector: "draft", want: "a.md:3"},
+		{name: "exact match wins over ambiguous partial", selector: "call sam", want: "a.md:4"},
+		{name: "ambiguous", selector: "call", wantErr: true},
+		{name: "no match", selector: "email", wantErr: true},
+		{name: "empty", selector: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectTask(nd, tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && location(got) != tt.want {
+				t.Errorf("selectTask() = %s, want %s", location(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    tasks.Status
+		wantErr bool
+	}{
+		{name: "todo", want: tasks.Todo},
+		{name: "doing", want: tasks.Doing},
+		{name: "blocked", want: tasks.Blocked},
+		{name: " Done ", want: tasks.Done},
+		{name: "ABANDONED", want: tasks.Abandoned},
+		{name: "x", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStatus(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseStatus() = %q, want %q", got, tt.want)
+			}
+			if !tt.wantErr && statusName(got) != strings.ToLower(strings.TrimSpace(tt.name)) {
+				t.Errorf("statusName() = %q, round trip of %q", statusName(got), tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testenv sets up the workspaces and program contexts shared by the tests.
+package testenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/themes"
+)
+
+// Workspace writes the files to a temporary workspace and opens it, returning the client along with the root.
+// Open is typically notedown.NewClient, it is passed in so the notedown package can use this in its own tests.
+func Workspace[C any](t *testing.T, open func(root string) (C, error), files map[string]string) (C, string) {
+	t.Helper()
+	root := t.TempDir()
+	for path, content := range files {
+		Write(t, root, path, content)
+	}
+	c, err := open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, root
+}
+
+// Write writes the file to the workspace, creating any missing directories.
+func Write(t *testing.T, root string, path string, content string) {
+	t.Helper()
+	abs := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(abs, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Read returns the content of the file in the workspace.
+func Read(t *testing.T, root string, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// View returns the initial view built by a program context whose clock is fixed at now.
+func View[M tea.Model](now time.Time, build func(*context.ProgramContext) M, opts ...context.ProgramContextOption) M {
+	var m M
+	context.New(themes.CatpuccinMocha, func(ctx *context.ProgramContext) tea.Model {
+		m = build(ctx)
+		return m
+	}, append([]context.ProgramContextOption{context.WithClock(func() time.Time { return now })}, opts...)...)
+	return m
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupedlist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/task/pkg/fuzzy"
+)
+
+// Matcher reports whether an item should be shown for the given filter query.
+type Matcher[T any] func(item T, query string) bool
+
+// DefaultMatcher fuzzy matches the query against the string representation of the item.
+func DefaultMatcher[T any](item T, query string) bool {
+	_, ok := fuzzy.Match(query, fmt.Sprint(item))
+	return ok
+}
+
+// Update handles the filter and selection key presses. It reports whether the key press was consumed by the list, in which case
+// the view shouldn't act on it any further. Unfocused lists never consume key presses.
+func (m *Model[T]) Update(msg tea.Msg) (bool, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focus {
+		return false, nil
+	}
+
+	if m.filtering {
+		switch {
+		case key.Matches(keyMsg, m.keyMap.AcceptFilter):
+			if m.filter.Value() == "" {
+				m.clearFilter()
+			} else {
+				m.filtering = false
+				m.filter.Blur()
+			}
+			return true, nil
+		case key.Matches(keyMsg, m.keyMap.ClearFilter):
+			m.clearFilter()
+			return true, nil
+		case keyMsg.Key().Mod.Contains(tea.ModCtrl):
+			return false, nil // leave the program level shortcuts (quit, navigation etc.) working while typing
+		}
+
+		previous := m.filter.Value()
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		if m.filter.Value() != previous {
+			m.cursor = 0
+			m.applyFilter()
+			m.viewport.SetYOffset(0)
+		}
+		return true, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keyMap.Filter):
+		if !m.Filtered() {
+			m.savedCursor = m.cursor
+		}
+		m.filtering = true
+		m.resize()
+		return true, m.filter.Focus()
+	case key.Matches(keyMsg, m.keyMap.Mark):
+		m.toggleMark()
+		return true, nil
+	case key.Matches(keyMsg, m.keyMap.MarkRange):
+		m.markRange()
+		return true, nil
+	case key.Matches(keyMsg, m.keyMap.MarkGroup):
+		m.toggleGroup()
+		return true, nil
+	case len(m.marked) > 0 && key.Matches(keyMsg, m.keyMap.ClearMarks):
+		m.ClearMarks()
+		return true, nil
+	case m.Filtered() && key.Matches(keyMsg, m.keyMap.ClearFilter):
+		m.clearFilter()
+		return true, nil
+	}
+	return false, nil
+}
+
+// Query returns the current filter query, empty if the list isn't filtered.
+func (m Model[T]) Query() string {
+	return m.filter.Value()
+}
+
+// Filtering reports whether the filter query is being typed.
+func (m Model[T]) Filtering() bool {
+	return m.filtering
+}
+
+// Filtered reports whether a filter is being typed or applied.
+func (m Model[T]) Filtered() bool {
+	return m.filtering || m.filter.Value() != ""
+}
+
+// applyFilter rebuilds the visible groups from the full set of groups using the current query.
+// Groups are kept even when empty so their order is stable, empty groups aren't rendered.
+func (m *Model[T]) applyFilter() {
+	query := m.filter.Value()
+	m.visible = make([]Group[T], 0, len(m.groups))
+	m.totalItems = 0
+	for _, group := range m.groups {
+		items := group.Items
+		if query != "" {
+			items = make([]T, 0)
+			for _, item := range group.Items {
+				if m.matcher(item, query) {
+					items = append(items, item)
+				}
+			}
+		}
+		m.visible = append(m.visible, Group[T]{Name: group.Name, Items: items})
+		m.totalItems += len(items)
+	}
+	m.cursor = clamp(m.cursor, 0, m.totalItems-1)
+	m.updateViewport()
+}
+
+// clearFilter removes the filter and puts the cursor back where it was before filtering started.
+func (m *Model[T]) clearFilter() {
+	m.filtering = false
+	m.filter.Blur()
+	m.filter.Reset()
+	m.cursor = m.savedCursor
+	m.applyFilter()
+	m.resize()
+	m.scrollToCursor()
+}
+
+func (m Model[T]) filterView() string {
+	m.filter.Width = max(m.viewport.Width-lipgloss.Width(m.filter.Prompt)-1, 0)
+	return m.filter.View()
+}
+
+// ParseQuery splits a filter query into the text to fuzzy match and the values of any tokens with one of the given
+// prefixes, e.g. "report path:work" with the prefix "path:" returns "report" and {"path:": "work"}.
+func ParseQuery(query string, prefixes ...string) (string, map[string]string) {
+	text := make([]string, 0)
+	values := make(map[string]string)
+	for _, token := range strings.Fields(query) {
+		prefixed := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(strings.ToLower(token), prefix) {
+				values[prefix] = token[len(prefix):]
+				prefixed = true
+				break
+			}
+		}
+		if !prefixed {
+			text = append(text, token)
+		}
+	}
+	return strings.Join(text, " "), values
+}
+
+// Highlight renders s with the runes matched by the query in the match style and the rest in the base style.
+// Both styles should set the background, see https://github.com/charmbracelet/lipgloss/issues/144.
+func Highlight(s string, query string, base lipgloss.Style, match lipgloss.Style) string {
+	indexes := fuzzy.Indexes(query, s)
+	if len(indexes) == 0 {
+		return base.Render(s)
+	}
+
+	var b strings.Builder
+	runes := []rune(s)
+	start, next := 0, 0
+	for i := range runes {
+		if next < len(indexes) && indexes[next] == i {
+			if start < i {
+				b.WriteString(base.Render(string(runes[start:i])))
+			}
+			b.WriteString(match.Render(string(runes[i])))
+			start = i + 1
+			next++
+		}
+	}
+	if start < len(runes) {
+		b.WriteString(base.Render(string(runes[start:])))
+	}
+	return b.String()
+}
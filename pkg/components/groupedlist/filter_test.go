@@ -0,0 +1,98 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupedlist
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantText   string
+		wantValues map[string]string
+	}{
+		{name: "text only", query: "write report", wantText: "write report", wantValues: map[string]string{}},
+		{name: "prefix", query: "report path:work", wantText: "report", wantValues: map[string]string{"path:": "work"}},
+		{name: "prefix first", query: "p:1 report", wantText: "report", wantValues: map[string]string{"p:": "1"}},
+		{name: "both prefixes", query: "path:work p:2", wantText: "", wantValues: map[string]string{"path:": "work", "p:": "2"}},
+		{name: "prefix ignores case", query: "PATH:Work", wantText: "", wantValues: map[string]string{"path:": "Work"}},
+		{name: "empty value", query: "report p:", wantText: "report", wantValues: map[string]string{"p:": ""}},
+		{name: "last value wins", query: "p:1 p:2", wantText: "", wantValues: map[string]string{"p:": "2"}},
+		{name: "prefix inside token", query: "xpath:work", wantText: "xpath:work", wantValues: map[string]string{}},
+		{name: "extra whitespace", query: "  write   report ", wantText: "write report", wantValues: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, values := ParseQuery(tt.query, "path:", "p:")
+			if text != tt.wantText {
+				t.Errorf("text = %q, want %q", text, tt.wantText)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestDefaultMatcher(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "", want: true},
+		{query: "wrt", want: true},
+		{query: "REPORT", want: true},
+		{query: "tw", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := DefaultMatcher("write report", tt.query); got != tt.want {
+				t.Errorf("DefaultMatcher(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	base := lipgloss.NewStyle()
+	match := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+
+	tests := []struct {
+		name  string
+		s     string
+		query string
+		want  string
+	}{
+		{name: "no query", s: "write report", query: "", want: "write report"},
+		{name: "no match", s: "write report", query: "xyz", want: "write report"},
+		{name: "prefix", s: "write report", query: "wr", want: "[w][r]ite report"},
+		{name: "spread", s: "write report", query: "wrp", want: "[w][r]ite re[p]ort"},
+		{name: "last rune", s: "write report", query: "t", want: "wri[t]e report"},
+		{name: "whole", s: "abc", query: "abc", want: "[a][b][c]"},
+		{name: "multibyte", s: "café au lait", query: "éa", want: "caf[é] [a]u lait"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.s, tt.query, base, match); got != tt.want {
+				t.Errorf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
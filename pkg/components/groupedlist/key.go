@@ -0,0 +1,80 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupedlist
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+// KeyMap holds the bindings handled by the list itself, these apply to every view that uses the list.
+type KeyMap struct {
+	Filter       key.Binding
+	AcceptFilter key.Binding
+	ClearFilter  key.Binding
+
+	Mark       key.Binding
+	MarkRange  key.Binding
+	MarkGroup  key.Binding
+	ClearMarks key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter the list"),
+	),
+	AcceptFilter: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "stop typing and keep the filter"),
+	),
+	ClearFilter: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear the filter"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys("space"),
+		key.WithHelp("space", "select/deselect the item"),
+	),
+	MarkRange: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "select every item from the last selected to the cursor"),
+	),
+	MarkGroup: key.NewBinding(
+		key.WithKeys("V", "shift+v"),
+		key.WithHelp("V", "select/deselect every item in the group"),
+	),
+	ClearMarks: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear the selection"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"Filter":       &k.Filter,
+		"AcceptFilter": &k.AcceptFilter,
+		"ClearFilter":  &k.ClearFilter,
+		"Mark":         &k.Mark,
+		"MarkRange":    &k.MarkRange,
+		"MarkGroup":    &k.MarkGroup,
+		"ClearMarks":   &k.ClearMarks,
+	}
+}
+
+// ModalActions only apply while the filter is being typed or items are marked, they take precedence over the
+// bindings of the view for the same keys (esc clears the selection before it goes back).
+var ModalActions = []string{"AcceptFilter", "ClearFilter", "ClearMarks"}
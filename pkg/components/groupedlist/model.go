@@ -15,6 +15,7 @@
 package groupedlist
 
 import (
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	"github.com/charmbracelet/bubbles/v2/viewport"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -34,24 +35,54 @@ type Group[T any] struct {
 type Renderers[T any] struct {
 	Header   func(string, int) string
 	Footer   func(string, int) string
-	Item     func(T, int) string
-	Selected func(T, int) string
+	Item     func(T, ItemState) string
+	Selected func(T, ItemState) string
+}
+
+// ItemState is passed to the item renderers.
+type ItemState struct {
+	Width  int
+	Query  string // the filter query, used to highlight the matching text, see Highlight
+	Marked bool   // whether the item is part of the multi-selection
 }
 
 type Model[T any] struct {
-	groups     []Group[T]
-	totalItems int
+	groups     []Group[T] // every group as given to SetGroups
+	visible    []Group[T] // the groups after the filter has been applied
+	totalItems int        // number of visible items
 	renderers  Renderers[T]
 
+	keyMap      KeyMap
+	matcher     Matcher[T]
+	filter      textinput.Model
+	filtering   bool // true while the filter query is being typed
+	savedCursor int  // cursor before filtering started, restored when the filter is cleared
+
+	identity Identity[T]
+	marked   map[string]struct{} // identities of the marked items
+	anchor   int                 // index of the last toggled item, where ranges are marked from
+
 	focus          bool
 	cursor         int // index of the selected item
 	cursorAbsolute int // index of the position in the viewport content
+	height         int
 	viewport       viewport.Model
 }
 
 func New[T any](opts ...Option[T]) *Model[T] {
+	filter := textinput.New()
+	filter.Prompt = "/ "
+
 	m := &Model[T]{
 		groups:   make([]Group[T], 0),
+		visible:  make([]Group[T], 0),
+		keyMap:   DefaultKeyMap,
+		matcher:  DefaultMatcher[T],
+		identity: DefaultIdentity[T],
+		marked:   make(map[string]struct{}),
+		anchor:   -1,
+		filter:   filter,
+		height:   20,
 		viewport: viewport.New(0, 20),
 	}
 	for _, opt := range opts {
@@ -62,22 +93,21 @@ func New[T any](opts ...Option[T]) *Model[T] {
 
 func (m *Model[T]) SetGroups(groups []Group[T]) {
 	m.groups = groups
-	m.totalItems = 0
-	for _, g := range groups {
-		m.totalItems += len(g.Items)
-	}
-
-	// Reset the cursor if it's now out of bounds
-	m.cursor = clamp(m.cursor, 0, m.totalItems-1)
+	m.pruneMarks()
 
-	m.updateViewport()
+	// Also resets the cursor if it's now out of bounds
+	m.applyFilter()
 
 	// Ensure the viewport finishes at the bottom of the content (no trailing whitespace)
 	if m.viewport.YOffset > m.viewport.TotalLineCount()-m.viewport.Height {
 		m.viewport.SetYOffset(m.viewport.TotalLineCount() - m.viewport.Height)
 	}
 
-	// If the cursor is now outside of the visible viewport, adjust the YOffset to make it visible
+	m.scrollToCursor()
+}
+
+// scrollToCursor adjusts the YOffset to make the cursor visible if it is outside of the visible viewport.
+func (m *Model[T]) scrollToCursor() {
 	if m.cursorAbsolute > m.viewport.YOffset+m.viewport.Height-1 || m.cursorAbsolute < m.viewport.YOffset {
 		m.viewport.SetYOffset(m.cursorAbsolute - m.viewport.Height/2)
 	}
@@ -128,14 +158,23 @@ func (m *Model[T]) Width(i int) *Model[T] {
 }
 
 func (m *Model[T]) Height(i int) *Model[T] {
-	m.viewport.Height = i
-	m.updateViewport()
+	m.height = i
+	m.resize()
 	return m
 }
 
+// resize sets the viewport height, leaving room for the filter when it is shown.
+func (m *Model[T]) resize() {
+	m.viewport.Height = m.height
+	if m.Filtered() {
+		m.viewport.Height = max(m.height-1, 0)
+	}
+	m.updateViewport()
+}
+
 func (m Model[T]) Selected() *T {
 	index := 0
-	for _, group := range m.groups {
+	for _, group := range m.visible {
 		for _, item := range group.Items {
 			if index == m.cursor {
 				return &item
@@ -149,21 +188,38 @@ func (m Model[T]) Selected() *T {
 // there are various optimizations that could be made here
 // right now we just re-render everything
 func (m *Model[T]) updateViewport() {
+	renderedLines, cursorAbsolute := m.render()
+	m.cursorAbsolute = cursorAbsolute
+	m.viewport.SetContent(
+		lipgloss.JoinVertical(lipgloss.Left, renderedLines...),
+	)
+}
+
+// Render returns every group rendered in full at the current width, ignoring the height of the viewport.
+// This is useful for printing the list outside of an interactive program.
+func (m Model[T]) Render() string {
+	renderedLines, _ := m.render()
+	return lipgloss.JoinVertical(lipgloss.Left, renderedLines...)
+}
+
+// render returns the rendered lines along with the index of the line the cursor is on
+func (m Model[T]) render() ([]string, int) {
 	renderedLines := make([]string, 0)
+	cursorAbsolute := m.cursorAbsolute
 
 	groupIndex := 0
 	itemIndex := 0
 	for {
-		if groupIndex >= len(m.groups) {
+		if groupIndex >= len(m.visible) {
 			break
 		}
-		group := m.groups[groupIndex]
+		group := m.visible[groupIndex]
 		if len(group.Items) != 0 {
 			renderedLines = m.renderHeader(renderedLines, groupIndex)
 			for i := 0; i < len(group.Items); i++ {
 				if itemIndex == m.cursor && m.focus {
 					renderedLines = m.renderSelected(renderedLines, groupIndex, i)
-					m.cursorAbsolute = len(renderedLines) - 1
+					cursorAbsolute = len(renderedLines) - 1
 				} else {
 					renderedLines = m.renderItem(renderedLines, groupIndex, i)
 				}
@@ -174,34 +230,42 @@ func (m *Model[T]) updateViewport() {
 		groupIndex++
 	}
 
-	m.viewport.SetContent(
-		lipgloss.JoinVertical(lipgloss.Left, renderedLines...),
-	)
+	return renderedLines, cursorAbsolute
 }
 
 func (m Model[T]) renderHeader(acc []string, group int) []string {
 	if m.renderers.Header == nil {
 		return acc
 	}
-	return append(acc, m.renderers.Header(m.groups[group].Name, m.viewport.Width))
+	return append(acc, m.renderers.Header(m.visible[group].Name, m.viewport.Width))
 }
 
 func (m Model[T]) renderFooter(acc []string, group int) []string {
 	if m.renderers.Footer == nil {
 		return acc
 	}
-	return append(acc, m.renderers.Footer(m.groups[group].Name, m.viewport.Width))
+	return append(acc, m.renderers.Footer(m.visible[group].Name, m.viewport.Width))
 }
 
 func (m Model[T]) renderItem(acc []string, group int, index int) []string {
-	return append(acc, m.renderers.Item(m.groups[group].Items[index], m.viewport.Width))
+	item := m.visible[group].Items[index]
+	return append(acc, m.renderers.Item(item, m.itemState(item)))
 }
 
 func (m Model[T]) renderSelected(acc []string, group int, index int) []string {
-	return append(acc, m.renderers.Selected(m.groups[group].Items[index], m.viewport.Width))
+	item := m.visible[group].Items[index]
+	return append(acc, m.renderers.Selected(item, m.itemState(item)))
+}
+
+func (m Model[T]) itemState(item T) ItemState {
+	_, marked := m.marked[m.identity(item)]
+	return ItemState{Width: m.viewport.Width, Query: m.filter.Value(), Marked: marked}
 }
 
 func (m Model[T]) View() string {
+	if m.Filtered() {
+		return lipgloss.JoinVertical(lipgloss.Left, m.filterView(), m.viewport.View())
+	}
 	return m.viewport.View()
 }
 
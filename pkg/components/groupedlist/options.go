@@ -21,3 +21,17 @@ func WithRenderers[T any](renderers Renderers[T]) Option[T] {
 		m.renderers = renderers
 	}
 }
+
+// WithMatcher sets the function used to filter items, defaults to DefaultMatcher.
+func WithMatcher[T any](matcher Matcher[T]) Option[T] {
+	return func(m *Model[T]) {
+		m.matcher = matcher
+	}
+}
+
+// WithIdentity sets the function used to keep track of marked items, defaults to DefaultIdentity.
+func WithIdentity[T any](identity Identity[T]) Option[T] {
+	return func(m *Model[T]) {
+		m.identity = identity
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupedlist
+
+import "fmt"
+
+// Identity returns a key that identifies an item across calls to SetGroups, it is used to keep track of the marked
+// items as the groups are refreshed.
+type Identity[T any] func(T) string
+
+// DefaultIdentity identifies items by their string representation.
+func DefaultIdentity[T any](item T) string {
+	return fmt.Sprint(item)
+}
+
+// Marked returns the visible marked items in the order they are displayed.
+func (m Model[T]) Marked() []T {
+	res := make([]T, 0, len(m.marked))
+	for _, group := range m.visible {
+		for _, item := range group.Items {
+			if _, ok := m.marked[m.identity(item)]; ok {
+				res = append(res, item)
+			}
+		}
+	}
+	return res
+}
+
+// Targets returns the marked items or, if nothing is marked, the item under the cursor.
+// This is what bulk operations should be applied to.
+func (m Model[T]) Targets() []T {
+	if marked := m.Marked(); len(marked) > 0 {
+		return marked
+	}
+	if selected := m.Selected(); selected != nil {
+		return []T{*selected}
+	}
+	return nil
+}
+
+func (m *Model[T]) ClearMarks() {
+	m.marked = make(map[string]struct{})
+	m.anchor = -1
+	m.updateViewport()
+}
+
+func (m *Model[T]) toggleMark() {
+	items := m.items()
+	if m.cursor < 0 || m.cursor >= len(items) {
+		return
+	}
+	id := m.identity(items[m.cursor])
+	if _, ok := m.marked[id]; ok {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = struct{}{}
+	}
+	m.anchor = m.cursor
+	m.updateViewport()
+}
+
+// markRange marks every item between the last toggled item and the cursor.
+func (m *Model[T]) markRange() {
+	items := m.items()
+	if m.cursor < 0 || m.cursor >= len(items) {
+		return
+	}
+	from := clamp(m.anchor, 0, len(items)-1)
+	if m.anchor < 0 {
+		from = m.cursor
+	}
+	for i := min(from, m.cursor); i <= max(from, m.cursor); i++ {
+		m.marked[m.identity(items[i])] = struct{}{}
+	}
+	m.anchor = m.cursor
+	m.updateViewport()
+}
+
+// toggleGroup marks every item in the cursor's group, or unmarks them if they are all marked already.
+func (m *Model[T]) toggleGroup() {
+	index := 0
+	for _, group := range m.visible {
+		if m.cursor >= index && m.cursor < index+len(group.Items) {
+			all := true
+			for _, item := range group.Items {
+				if _, ok := m.marked[m.identity(item)]; !ok {
+					all = false
+					break
+				}
+			}
+			for _, item := range group.Items {
+				if all {
+					delete(m.marked, m.identity(item))
+				} else {
+					m.marked[m.identity(item)] = struct{}{}
+				}
+			}
+			break
+		}
+		index += len(group.Items)
+	}
+	m.updateViewport()
+}
+
+// pruneMarks forgets marked items that are no longer in any group.
+func (m *Model[T]) pruneMarks() {
+	present := make(map[string]struct{}, len(m.marked))
+	for _, group := range m.groups {
+		for _, item := range group.Items {
+			id := m.identity(item)
+			if _, ok := m.marked[id]; ok {
+				present[id] = struct{}{}
+			}
+		}
+	}
+	m.marked = present
+}
+
+// items returns the visible items in the order they are displayed.
+func (m Model[T]) items() []T {
+	res := make([]T, 0, m.totalItems)
+	for _, group := range m.visible {
+		res = append(res, group.Items...)
+	}
+	return res
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupedlist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestList(groups ...Group[string]) *Model[string] {
+	render := func(s string, _ ItemState) string { return s }
+	m := New(WithRenderers(Renderers[string]{Item: render, Selected: render}))
+	m.Focus()
+	m.SetGroups(groups)
+	return m
+}
+
+// twoGroups is displayed as a1 a2 a3 b1 b2.
+func twoGroups() []Group[string] {
+	return []Group[string]{
+		{Name: "a", Items: []string{"a1", "a2", "a3"}},
+		{Name: "empty"},
+		{Name: "b", Items: []string{"b1", "b2"}},
+	}
+}
+
+func TestMarkRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		anchor int // cursor position toggled before the range is marked, -1 for none
+		cursor int
+		want   []string
+	}{
+		{name: "no anchor marks the cursor", anchor: -1, cursor: 2, want: []string{"a3"}},
+		{name: "same item", anchor: 1, cursor: 1, want: []string{"a2"}},
+		{name: "down within a group", anchor: 0, cursor: 2, want: []string{"a1", "a2", "a3"}},
+		{name: "down across groups", anchor: 1, cursor: 3, want: []string{"a2", "a3", "b1"}},
+		{name: "up across groups", anchor: 4, cursor: 2, want: []string{"a3", "b1", "b2"}},
+		{name: "first to last", anchor: 0, cursor: 4, want: []string{"a1", "a2", "a3", "b1", "b2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestList(twoGroups()...)
+			if tt.anchor >= 0 {
+				m.cursor = tt.anchor
+				m.toggleMark()
+			}
+			m.cursor = tt.cursor
+			m.markRange()
+			if got := m.Marked(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Marked() = %v, want %v", got, tt.want)
+			}
+			if m.anchor != tt.cursor {
+				t.Errorf("anchor = %d, want the cursor %d", m.anchor, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestMarkRangeAfterShrinking(t *testing.T) {
+	m := newTestList(twoGroups()...)
+	m.cursor = 4
+	m.toggleMark() // b2, the anchor is now past the end of the shrunk list
+	m.SetGroups([]Group[string]{{Name: "a", Items: []string{"a1", "a2", "a3"}}})
+	m.cursor = 0
+	m.markRange()
+	if got, want := m.Marked(), []string{"a1", "a2", "a3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marked() = %v, want %v", got, want)
+	}
+}
+
+func TestToggleGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		marked []int // cursor positions toggled first
+		cursor int
+		want   []string
+	}{
+		{name: "first item of a group", cursor: 0, want: []string{"a1", "a2", "a3"}},
+		{name: "last item of a group", cursor: 2, want: []string{"a1", "a2", "a3"}},
+		{name: "first item of the next group", cursor: 3, want: []string{"b1", "b2"}},
+		{name: "partially marked", marked: []int{1}, cursor: 0, want: []string{"a1", "a2", "a3"}},
+		{name: "fully marked", marked: []int{3, 4}, cursor: 4, want: []string{}},
+		{name: "other groups untouched", marked: []int{0, 1, 2, 3}, cursor: 4, want: []string{"a1", "a2", "a3", "b1", "b2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestList(twoGroups()...)
+			for _, i := range tt.marked {
+				m.cursor = i
+				m.toggleMark()
+			}
+			m.cursor = tt.cursor
+			m.toggleGroup()
+			if got := m.Marked(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Marked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneMarks(t *testing.T) {
+	m := newTestList(twoGroups()...)
+	for i := range 5 {
+		m.cursor = i
+		m.toggleMark()
+	}
+
+	// b1 is removed, a2 moves to the other group and a3 is hidden by the filter
+	m.SetGroups([]Group[string]{
+		{Name: "a", Items: []string{"a1", "a3"}},
+		{Name: "b", Items: []string{"a2", "b2", "c1"}},
+	})
+	m.filter.SetValue("a1 a2 b2")
+	m.matcher = func(item string, query string) bool { return item != "a3" }
+	m.applyFilter()
+
+	if got, want := m.Marked(), []string{"a1", "a2", "b2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marked() = %v, want %v", got, want)
+	}
+	if _, ok := m.marked["b1"]; ok {
+		t.Errorf("b1 is still marked after it was removed")
+	}
+	if _, ok := m.marked["a3"]; !ok {
+		t.Errorf("a3 was unmarked while hidden by the filter")
+	}
+
+	m.filter.SetValue("")
+	m.applyFilter()
+	if got, want := m.Marked(), []string{"a1", "a3", "a2", "b2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marked() after clearing the filter = %v, want %v", got, want)
+	}
+}
+
+func TestTargets(t *testing.T) {
+	m := newTestList(twoGroups()...)
+	m.cursor = 3
+	if got, want := m.Targets(), []string{"b1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Targets() without marks = %v, want %v", got, want)
+	}
+	m.cursor = 0
+	m.toggleMark()
+	m.cursor = 3
+	if got, want := m.Targets(), []string{"a1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Targets() with marks = %v, want %v", got, want)
+	}
+	m.ClearMarks()
+	if got := newTestList().Targets(); len(got) != 0 {
+		t.Errorf("Targets() of an empty list = %v, want none", got)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/v2/help"
+	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/notedownorg/task/pkg/context"
@@ -70,8 +72,12 @@ type Model struct {
 	message       string
 	messageExpire time.Time
 	messageColor  lipgloss.Color
+	messageSet    time.Time
 
 	mode Mode
+
+	// keys is the key map of the owning view, when set a one-line hint row is rendered below the bar.
+	keys help.KeyMap
 }
 
 func New(ctx *context.ProgramContext, mode Mode, nd notedown.Client) *Model {
@@ -94,9 +100,52 @@ func (m *Model) SetMessage(message string, until time.Time, color lipgloss.Color
 	m.messageExpire = until
 	m.message = message
 	m.messageColor = color
+	m.messageSet = time.Now()
+	return m
+}
+
+// SetMode replaces the mode shown at the start of the bar.
+func (m *Model) SetMode(mode Mode) *Model {
+	m.mode = mode
 	return m
 }
 
+// SetHelp sets the key map used to render the hint row below the bar.
+func (m *Model) SetHelp(keys help.KeyMap) *Model {
+	m.keys = keys
+	return m
+}
+
+// SetError reports a failed operation with the class of the error and a hint of how to fix it, verb describes the
+// operation e.g. "save task". If retrying may help the key of the retry binding is included.
+func (m *Model) SetError(verb string, err error, retry key.Binding) *Model {
+	class, hint, retryable := notedown.Classify(err)
+	message := fmt.Sprintf("%s: failed to %s, %s", class, verb, hint)
+	if retryable && len(retry.Keys()) > 0 {
+		message += fmt.Sprintf(" (%s to retry)", retry.Help().Key)
+	}
+	return m.SetMessage(message, time.Now().Add(30*time.Second), m.ctx.Theme.Red)
+}
+
+// SetTaskErrors reports each task an operation failed for, verb describes the operation e.g. "complete".
+func (m *Model) SetTaskErrors(verb string, total int, errs []notedown.TaskError) *Model {
+	if len(errs) == 0 {
+		return m
+	}
+	return m.SetMessage(taskErrors(verb, total, errs), time.Now().Add(10*time.Second), m.ctx.Theme.Red)
+}
+
+func taskErrors(verb string, total int, errs []notedown.TaskError) string {
+	if total == 1 {
+		return fmt.Sprintf("failed to %s task: %v", verb, errs[0])
+	}
+	failures := make([]string, 0, len(errs))
+	for _, err := range errs {
+		failures = append(failures, err.Error())
+	}
+	return fmt.Sprintf("failed to %s %d of %d tasks: %s", verb, len(errs), total, strings.Join(failures, "; "))
+}
+
 func (m *Model) Width(width int) *Model {
 	m.base.Width(width)
 	return m
@@ -122,7 +171,12 @@ func (m *Model) View() string {
 
 	w := lipgloss.Width
 	statusBlockWidth := m.base.AvailableWidth() - w(statsBlock) - w(modeBlock)
-	statusBlock := textStyle(m.ctx.Theme).Foreground(m.messageColor).Align(lipgloss.Center).Width(statusBlockWidth).Render(m.message)
+	message, color := m.message, m.messageColor
+	// Notices are program-wide (e.g. undo), show whichever of it and the message was set last
+	if notice, ok := m.ctx.Notice(); ok && (message == "" || notice.Set.After(m.messageSet)) {
+		message, color = notice.Text, notice.Color
+	}
+	statusBlock := textStyle(m.ctx.Theme).Foreground(color).Align(lipgloss.Center).Width(statusBlockWidth).Render(message)
 
 	bar := lipgloss.JoinHorizontal(lipgloss.Top,
 		modeBlock,
@@ -130,6 +184,22 @@ func (m *Model) View() string {
 		statsBlock,
 	)
 
+	if m.keys != nil {
+		bar = lipgloss.JoinVertical(lipgloss.Left, bar, m.hints())
+	}
+
 	return m.base.NewStyle().
 		Render(bar)
 }
+
+// hints renders the short help of the view followed by a pointer to the help overlay, truncated to the bar width.
+func (m *Model) hints() string {
+	h := help.New()
+	h.Width = m.base.AvailableWidth()
+	h.Styles.ShortKey = lipgloss.NewStyle().Foreground(m.ctx.Theme.Text)
+	h.Styles.ShortDesc = lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint)
+	h.Styles.ShortSeparator = lipgloss.NewStyle().Foreground(m.ctx.Theme.BorderFaint)
+	h.Styles.Ellipsis = h.Styles.ShortSeparator
+	bindings := append([]key.Binding{context.DefaultGlobalKeyMap.Help}, m.keys.ShortHelp()...)
+	return h.ShortHelpView(bindings)
+}
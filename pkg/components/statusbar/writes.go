@@ -0,0 +1,51 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusbar
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+// WrittenEvent is the result of the writes started with Write, Errs holds a failure for each task that wasn't written.
+type WrittenEvent struct {
+	source *Model
+	Verb   string
+	Total  int
+	Errs   []notedown.TaskError
+}
+
+// Failure describes the tasks that weren't written, the program reports it as a notice so it's still shown if the view
+// that started the writes is no longer active.
+func (e WrittenEvent) Failure() string {
+	if len(e.Errs) == 0 {
+		return ""
+	}
+	return taskErrors(e.Verb, e.Total, e.Errs)
+}
+
+// From reports whether the writes were started with the given statusbar.
+func (e WrittenEvent) From(m *Model) bool {
+	return e.source == m
+}
+
+// Write runs the writes in the background and sends the result as a WrittenEvent. Bulk writes wait for each document
+// to be reloaded between writes so running them in Update would freeze the program. Verb and total describe the
+// writes in error messages e.g. "complete" and the number of tasks.
+func (m *Model) Write(verb string, total int, write func() []notedown.TaskError) tea.Cmd {
+	return func() tea.Msg {
+		return WrittenEvent{source: m, Verb: verb, Total: total, Errs: write()}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusbar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+func TestWrittenEventIsReportedByTheProgram(t *testing.T) {
+	task := tasks.NewTask(tasks.NewIdentifier("a.md", "", 2), "Buy milk", tasks.Todo)
+	tests := []struct {
+		name string
+		errs []notedown.TaskError
+		want string // the notice, empty for none
+	}{
+		{name: "written", errs: nil, want: ""},
+		{name: "failed", errs: []notedown.TaskError{{Task: task, Err: errors.New("boom")}}, want: "failed to complete 1 of 2 tasks: a.md:2: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testenv.View(time.Now(), func(ctx *context.ProgramContext) *Model { return New(ctx, NewMode("agenda", ActionNeutral), nil) })
+
+			// The view that started the writes may no longer be active, the program reports the result regardless
+			msg := m.Write("complete", 2, func() []notedown.TaskError { return tt.errs })()
+			m.ctx.Update(msg)
+
+			notice, ok := m.ctx.Notice()
+			if got := notice.Text; ok != (tt.want != "") || got != tt.want {
+				t.Errorf("notice = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Action is a named operation of a view that can be triggered by its key binding or from the command palette.
+// The name of the action is the help description of the binding.
+// Run returns a non-nil model when the action navigates to a new view.
+type Action struct {
+	Binding key.Binding
+	Run     func() (tea.Model, tea.Cmd)
+}
+
+func (a Action) Name() string {
+	return a.Binding.Help().Desc
+}
+
+// ActionProvider is implemented by views that contribute their actions to the command palette.
+// Views should dispatch their key presses through MatchAction so the palette and the key bindings share a code path.
+type ActionProvider interface {
+	Actions() []Action
+}
+
+// MatchAction returns the first action whose binding matches the key press.
+func MatchAction(msg tea.KeyMsg, actions []Action) (Action, bool) {
+	for _, action := range actions {
+		if key.Matches(msg, action.Binding) {
+			return action, true
+		}
+	}
+	return Action{}, false
+}
@@ -14,36 +14,144 @@
 
 package context
 
-import tea "github.com/charmbracelet/bubbletea/v2"
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// GlobalKeyMap holds the bindings for the program-wide key handlers.
+type GlobalKeyMap struct {
+	Quit     key.Binding
+	Back     key.Binding
+	Agenda   key.Binding
+	Projects key.Binding
+	Week     key.Binding
+	Calendar key.Binding
+	Help     key.Binding
+	Palette  key.Binding
+	Undo     key.Binding
+	Redo     key.Binding
+
+	// Unbound by default, these can be run from the command palette or bound in the config file
+	AddTask    key.Binding
+	AddProject key.Binding
+}
+
+var DefaultGlobalKeyMap = GlobalKeyMap{
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q/ctrl+c", "quit"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "go back to the previous view"),
+	),
+	Agenda: key.NewBinding(
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "open the agenda"),
+	),
+	Projects: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "open the project list"),
+	),
+	Week: key.NewBinding(
+		key.WithKeys("ctrl+w"),
+		key.WithHelp("ctrl+w", "open the week view"),
+	),
+	Calendar: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("ctrl+l", "open the month calendar"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "show help"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys(":", "ctrl+k"),
+		key.WithHelp(":/ctrl+k", "open the command palette"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "undo the last change"),
+	),
+	Redo: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "redo the last undone change"),
+	),
+	AddTask: key.NewBinding(
+		key.WithHelp("", "add a task to today's daily note"),
+	),
+	AddProject: key.NewBinding(
+		key.WithHelp("", "add a new project"),
+	),
+}
+
+func (k *GlobalKeyMap) Bindings() Bindings {
+	return Bindings{
+		"Quit":       &k.Quit,
+		"Back":       &k.Back,
+		"Agenda":     &k.Agenda,
+		"Projects":   &k.Projects,
+		"Week":       &k.Week,
+		"Calendar":   &k.Calendar,
+		"Help":       &k.Help,
+		"Palette":    &k.Palette,
+		"Undo":       &k.Undo,
+		"Redo":       &k.Redo,
+		"AddTask":    &k.AddTask,
+		"AddProject": &k.AddProject,
+	}
+}
 
 // GlobalKeyHandlers are used to handle key events at the program level.
 // Typically these will be used for program-wide key bindings like quitting or top-level navigation.
-type GlobalKeyHandler func(*ProgramContext, tea.KeyMsg) (tea.Model, tea.Cmd)
+// Handle is only called for key events that match the Binding, the binding's help is shown in the help overlay.
+type GlobalKeyHandler struct {
+	Binding key.Binding
+	Handle  func(*ProgramContext) (tea.Model, tea.Cmd)
+
+	// Unlisted handlers aren't offered as commands in the command palette, e.g. the one that opens it.
+	Unlisted bool
+}
 
 // SetGlobalKeyHandler replaces the set of global key handlers in the ProgramContext.
-// Each handler will be called in order until one returns a non-nil model or command i.e. the handlers are OR'd.
+// Each matching handler will be called in order until one returns a non-nil model or command i.e. the handlers are OR'd.
 // If you want to do AND logic, you should handle that in a single handler.
 func (p *ProgramContext) SetGlobalKeyHandlers(handlers ...GlobalKeyHandler) *ProgramContext {
 	p.KeyHandlers = handlers
 	return p
 }
 
+// GlobalBindings returns the bindings of the registered global key handlers in the order they were registered.
+func (p *ProgramContext) GlobalBindings() []key.Binding {
+	res := make([]key.Binding, 0, len(p.KeyHandlers))
+	for _, handler := range p.KeyHandlers {
+		res = append(res, handler.Binding)
+	}
+	return res
+}
+
+// InputCapturer is implemented by views that accept free text input (e.g. a focused text field).
+// While a view is capturing input, global key handlers bound to printable characters are skipped so that
+// those characters can be typed.
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
 func HandleQuit() GlobalKeyHandler {
-	return func(ctx *ProgramContext, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-		switch msg.String() {
-		case "q", "ctrl+c":
+	return GlobalKeyHandler{
+		Binding: DefaultGlobalKeyMap.Quit,
+		Handle: func(ctx *ProgramContext) (tea.Model, tea.Cmd) {
 			return ctx, tea.Quit
-		}
-		return nil, nil
+		},
 	}
 }
 
 func HandleBack() GlobalKeyHandler {
-	return func(ctx *ProgramContext, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-		switch msg.String() {
-		case "esc":
+	return GlobalKeyHandler{
+		Binding: DefaultGlobalKeyMap.Back,
+		Handle: func(ctx *ProgramContext) (tea.Model, tea.Cmd) {
 			return ctx.Back(), nil
-		}
-		return nil, nil
+		},
 	}
 }
@@ -0,0 +1,138 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+// Bindings maps action names to the key bindings that trigger them.
+// Each view exposes its KeyMap as Bindings so that actions can be remapped by name from the config file.
+type Bindings map[string]*key.Binding
+
+// KeyMaps is the collection of every view's Bindings keyed by the name of the view.
+type KeyMaps map[string]Bindings
+
+// GlobalView is the name used for the global key map in KeyMaps and in the config file.
+const GlobalView = "global"
+
+// Override replaces the keys of the named actions. View and action names are matched case-insensitively
+// as the config file is case-insensitive. Unknown views or actions are reported as errors.
+func (k KeyMaps) Override(overrides map[string]map[string][]string) error {
+	errs := make([]string, 0)
+	for viewName, actions := range overrides {
+		bindings, ok := k.lookupView(viewName)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown view %q", viewName))
+			continue
+		}
+		for actionName, keys := range actions {
+			binding, ok := bindings.lookup(actionName)
+			if !ok {
+				errs = append(errs, fmt.Sprintf("unknown action %q for view %q", actionName, viewName))
+				continue
+			}
+			if len(keys) == 0 {
+				errs = append(errs, fmt.Sprintf("no keys given for %s.%s", viewName, actionName))
+				continue
+			}
+			binding.SetKeys(keys...)
+			binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("invalid key bindings:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// Component describes the key map of a component, such as the grouped list, that handles key presses before the
+// views it is part of. Modal actions only apply in a state of the component (e.g. while the filter is being typed)
+// where they deliberately take precedence over the view, so they are left out of the conflict checks.
+type Component struct {
+	Views []string
+	Modal []string
+}
+
+// Conflicts returns a description of every key that triggers more than one action in the same view.
+// The global bindings are active in every view so they are checked against each view as well as each other.
+// The bindings of a component are checked against each view it is part of, the components are keyed by the name of
+// their key map.
+func (k KeyMaps) Conflicts(components map[string]Component) []string {
+	conflicts := make(map[string]struct{})
+
+	check := func(views ...string) {
+		used := make(map[string][]string)
+		for _, view := range views {
+			for action, binding := range k[view] {
+				if c, ok := components[view]; ok && slices.Contains(c.Modal, action) {
+					continue
+				}
+				for _, key := range binding.Keys() {
+					used[key] = append(used[key], fmt.Sprintf("%s.%s", view, action))
+				}
+			}
+		}
+		for key, actions := range used {
+			if len(actions) > 1 {
+				sort.Strings(actions)
+				conflicts[fmt.Sprintf("%q is bound to %s", key, strings.Join(actions, " and "))] = struct{}{}
+			}
+		}
+	}
+
+	check(GlobalView)
+	for view := range k {
+		if view != GlobalView {
+			check(GlobalView, view)
+		}
+	}
+	for name, c := range components {
+		for _, view := range c.Views {
+			check(GlobalView, view, name)
+		}
+	}
+
+	res := make([]string, 0, len(conflicts))
+	for c := range conflicts {
+		res = append(res, c)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (k KeyMaps) lookupView(name string) (Bindings, bool) {
+	for view, bindings := range k {
+		if strings.EqualFold(view, name) {
+			return bindings, true
+		}
+	}
+	return nil, false
+}
+
+func (b Bindings) lookup(name string) (*key.Binding, bool) {
+	for action, binding := range b {
+		if strings.EqualFold(action, name) {
+			return binding, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Notice is a program-wide message shown in the statusbar of whichever view is active.
+type Notice struct {
+	Text   string
+	Color  lipgloss.Color
+	Set    time.Time
+	Expire time.Time
+}
+
+// Notify sets the notice, replacing any previous one, until the given duration has passed.
+func (c *ProgramContext) Notify(text string, color lipgloss.Color, d time.Duration) {
+	now := time.Now()
+	c.notice = Notice{Text: text, Color: color, Set: now, Expire: now.Add(d)}
+}
+
+// Notice returns the current notice, false if there is none or it has expired.
+func (c *ProgramContext) Notice() (Notice, bool) {
+	if c.notice.Text == "" || time.Now().After(c.notice.Expire) {
+		return Notice{}, false
+	}
+	return c.notice, true
+}
+
+// Reporter is implemented by the results of commands that run in the background, e.g. bulk writes. Their failures are
+// set as the notice when they reach the program so they're shown whichever view is active by the time they finish.
+type Reporter interface {
+	// Failure describes what failed, empty if nothing did.
+	Failure() string
+}
@@ -17,7 +17,9 @@ package context
 import (
 	"time"
 
+	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/task/pkg/dates"
 	"github.com/notedownorg/task/pkg/themes"
 )
 
@@ -39,6 +41,18 @@ type ProgramContext struct {
 
 	KeyHandlers []GlobalKeyHandler
 
+	// notice is shown in the statusbar of the active view, see Notify.
+	notice Notice
+
+	// weekStart is the first day of the week for any views that display whole weeks.
+	weekStart time.Weekday
+
+	// calendar is the work week and holidays used when rescheduling and to highlight days off.
+	calendar dates.Calendar
+
+	// confirm is the set of actions that must be confirmed before they run, see Confirms.
+	confirm map[string]bool
+
 	// clock acts as the "system" clock for the program, if nil uses time.Now()
 	// typically this would only be set (pinned) for testing purposes.
 	clock func() time.Time
@@ -54,6 +68,29 @@ func WithClock(fn func() time.Time) ProgramContextOption {
 	}
 }
 
+func WithWeekStart(day time.Weekday) ProgramContextOption {
+	return func(p *ProgramContext) {
+		p.weekStart = day
+	}
+}
+
+// WithCalendar sets the work week and holidays.
+func WithCalendar(cal dates.Calendar) ProgramContextOption {
+	return func(p *ProgramContext) {
+		p.calendar = cal
+	}
+}
+
+// WithConfirmations sets the actions that must be confirmed before they run.
+func WithConfirmations(actions ...string) ProgramContextOption {
+	return func(p *ProgramContext) {
+		p.confirm = make(map[string]bool)
+		for _, action := range actions {
+			p.confirm[action] = true
+		}
+	}
+}
+
 type InitalViewBuilder func(*ProgramContext) tea.Model
 
 func New(theme themes.Theme, initial InitalViewBuilder, opts ...ProgramContextOption) *ProgramContext {
@@ -80,13 +117,29 @@ func (c *ProgramContext) View() string {
 func (c *ProgramContext) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		capturing := false
+		if current, ok := c.History.Peek(); ok {
+			if ic, ok := current.(InputCapturer); ok {
+				capturing = ic.CapturingInput()
+			}
+		}
 		for _, handler := range c.KeyHandlers {
-			if m, cmd := handler(c, msg); m != nil || cmd != nil {
+			if capturing && msg.Key().Text != "" {
+				continue
+			}
+			if !key.Matches(msg, handler.Binding) {
+				continue
+			}
+			if m, cmd := handler.Handle(c); m != nil || cmd != nil {
 				return m, cmd
 			}
 		}
 	case tea.WindowSizeMsg:
 		c.onWindowResize(msg)
+	case Reporter:
+		if failure := msg.Failure(); failure != "" {
+			c.Notify(failure, c.Theme.Red, 10*time.Second)
+		}
 	}
 	return nil, c.Listeners.Receive(msg)
 }
@@ -98,6 +151,21 @@ func (c ProgramContext) Now() time.Time {
 	return c.clock()
 }
 
+// WeekStart returns the configured first day of the week, Sunday if it has not been set.
+func (c ProgramContext) WeekStart() time.Weekday {
+	return c.weekStart
+}
+
+// Calendar returns the configured work week and holidays, by default every day is a working day.
+func (c ProgramContext) Calendar() dates.Calendar {
+	return c.calendar
+}
+
+// Confirms reports whether the named action must be confirmed before it runs.
+func (c ProgramContext) Confirms(action string) bool {
+	return c.confirm[action]
+}
+
 func (c *ProgramContext) onWindowResize(msg tea.WindowSizeMsg) {
 	c.ScreenHeight = msg.Height
 	c.ScreenWidth = msg.Width
@@ -0,0 +1,57 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Undoer reverts and reapplies changes, returning a description of the change.
+type Undoer interface {
+	Undo() (string, error)
+	Redo() (string, error)
+}
+
+func HandleUndo(u Undoer) GlobalKeyHandler {
+	return GlobalKeyHandler{
+		Binding: DefaultGlobalKeyMap.Undo,
+		Handle: func(ctx *ProgramContext) (tea.Model, tea.Cmd) {
+			ctx.notifyUndo("undid", u.Undo)
+			return nil, nil
+		},
+	}
+}
+
+func HandleRedo(u Undoer) GlobalKeyHandler {
+	return GlobalKeyHandler{
+		Binding: DefaultGlobalKeyMap.Redo,
+		Handle: func(ctx *ProgramContext) (tea.Model, tea.Cmd) {
+			ctx.notifyUndo("redid", u.Redo)
+			return nil, nil
+		},
+	}
+}
+
+func (c *ProgramContext) notifyUndo(verb string, fn func() (string, error)) {
+	description, err := fn()
+	if err != nil {
+		c.Notify(err.Error(), c.Theme.Red, 10*time.Second)
+		return
+	}
+	c.Notify(fmt.Sprintf("%s %s", verb, description), c.Theme.Green, 5*time.Second)
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dates
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Calendar knows which days are worked. The zero value works every day and has no holidays so day offsets count
+// calendar days.
+type Calendar struct {
+	// WorkingDaysOnly makes day offsets count working days, skipping non-working days and holidays.
+	WorkingDaysOnly bool
+
+	week     map[time.Weekday]bool // nil works every day of the week
+	holidays map[string]bool
+}
+
+// NewCalendar returns a calendar that works the given days of the week, excluding the holidays.
+func NewCalendar(workingDaysOnly bool, week []time.Weekday, holidays []time.Time) Calendar {
+	c := Calendar{WorkingDaysOnly: workingDaysOnly, week: make(map[time.Weekday]bool), holidays: make(map[string]bool)}
+	for _, day := range week {
+		c.week[day] = true
+	}
+	for _, date := range holidays {
+		c.holidays[date.Format(Layout)] = true
+	}
+	return c
+}
+
+// IsWeekend reports whether the date falls outside the work week.
+func (c Calendar) IsWeekend(date time.Time) bool {
+	return c.week != nil && !c.week[date.Weekday()]
+}
+
+// IsHoliday reports whether the date is one of the holidays.
+func (c Calendar) IsHoliday(date time.Time) bool {
+	return c.holidays[date.Format(Layout)]
+}
+
+// IsWorkingDay reports whether the date is neither a weekend nor a holiday.
+func (c Calendar) IsWorkingDay(date time.Time) bool {
+	return !c.IsWeekend(date) && !c.IsHoliday(date)
+}
+
+// AddDays moves the date by n days, counting only working days when WorkingDaysOnly is set.
+func (c Calendar) AddDays(date time.Time, n int) time.Time {
+	if !c.WorkingDaysOnly || !c.works() {
+		return date.AddDate(0, 0, n)
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		date = date.AddDate(0, 0, step)
+		if c.IsWorkingDay(date) {
+			n--
+		}
+	}
+	return date
+}
+
+// Forward moves the date on to the next working day when WorkingDaysOnly is set and it is not one already.
+func (c Calendar) Forward(date time.Time) time.Time {
+	return c.roll(date, 1)
+}
+
+// Back moves the date back to the previous working day when WorkingDaysOnly is set and it is not one already.
+func (c Calendar) Back(date time.Time) time.Time {
+	return c.roll(date, -1)
+}
+
+func (c Calendar) roll(date time.Time, step int) time.Time {
+	if !c.WorkingDaysOnly || !c.works() {
+		return date
+	}
+	for !c.IsWorkingDay(date) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date
+}
+
+// works reports whether any day of the week is worked, without one there is no working day to move to.
+func (c Calendar) works() bool {
+	return c.week == nil || len(c.week) > 0
+}
+
+// LoadHolidays reads the holidays from an iCalendar (.ics) file, using the start date of each event, or from a plain
+// file with a date (2006-01-02) at the start of each line. Blank lines and lines starting with # are ignored.
+func LoadHolidays(path string) ([]time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ics := strings.EqualFold(filepath.Ext(path), ".ics")
+	holidays := make([]time.Time, 0)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		var date time.Time
+		switch {
+		case ics:
+			// e.g. DTSTART;VALUE=DATE:20261225 or DTSTART:20261225T000000Z
+			if !strings.HasPrefix(strings.ToUpper(line), "DTSTART") {
+				continue
+			}
+			value := line[strings.LastIndex(line, ":")+1:]
+			if len(value) < 8 {
+				return nil, fmt.Errorf("%s:%d: invalid event start %q", path, n, value)
+			}
+			date, err = time.Parse("20060102", value[:8])
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		default:
+			date, err = time.Parse(Layout, strings.Fields(line)[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid date: %w", path, n, err)
+		}
+		holidays = append(holidays, date)
+	}
+	return holidays, scanner.Err()
+}
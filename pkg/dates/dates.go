@@ -0,0 +1,141 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dates
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout is the format dates are written in.
+const Layout = "2006-01-02"
+
+var offset = regexp.MustCompile(`^([+-]?)(\d+)([dwmy])$`)
+
+// Parse resolves a date expression relative to now. Along with ISO dates (2006-01-02) it accepts:
+//
+//   - today, tomorrow (tom, tmr) and yesterday
+//   - a weekday (fri, friday), the next one after today
+//   - an offset such as +3d, 2w, -1m or +1y
+//   - next-week, next-month and next-year, the first day of each
+//   - eow, eom and eoy, the last day of the current week, month and year
+//
+// weekStart is the first day of the week used by next-week and eow. When the calendar counts working days only,
+// tomorrow, yesterday and day offsets count working days, the other expressions move forward to the next working
+// day, apart from the ends of periods which move back to the previous one, or on to the end of the next period if
+// that has already passed. ISO dates and weekdays are left as they are. The result is at midnight UTC as that is how
+// task dates are stored.
+func Parse(s string, now time.Time, weekStart time.Weekday, cal Calendar) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if date, err := time.Parse(Layout, s); err == nil {
+		return date, true
+	}
+
+	switch s {
+	case "today":
+		return today, true
+	case "tomorrow", "tom", "tmr":
+		return cal.AddDays(today, 1), true
+	case "yesterday":
+		return cal.AddDays(today, -1), true
+	case "next-week":
+		return cal.Forward(startOfWeek(today, weekStart).AddDate(0, 0, 7)), true
+	case "next-month":
+		return cal.Forward(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC)), true
+	case "next-year":
+		return cal.Forward(time.Date(today.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)), true
+	case "eow":
+		return endOf(cal, today, func(n int) time.Time { return startOfWeek(today, weekStart).AddDate(0, 0, 7*n+6) }), true
+	case "eom":
+		return endOf(cal, today, func(n int) time.Time {
+			return time.Date(today.Year(), today.Month()+time.Month(n)+1, 0, 0, 0, 0, 0, time.UTC)
+		}), true
+	case "eoy":
+		return endOf(cal, today, func(n int) time.Time { return time.Date(today.Year()+n, 12, 31, 0, 0, 0, 0, time.UTC) }), true
+	}
+
+	if day, ok := weekday(s); ok {
+		days := (int(day)-int(today.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days), true
+	}
+
+	if match := offset.FindStringSubmatch(s); match != nil {
+		n, _ := strconv.Atoi(match[2])
+		if match[1] == "-" {
+			n = -n
+		}
+		switch match[3] {
+		case "d":
+			return cal.AddDays(today, n), true
+		case "w":
+			return cal.Forward(today.AddDate(0, 0, 7*n)), true
+		case "m":
+			return cal.Forward(today.AddDate(0, n, 0)), true
+		case "y":
+			return cal.Forward(today.AddDate(n, 0, 0)), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func weekday(s string) (time.Weekday, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), s) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// endOf returns the last working day of the current period, or of the next one if it has already passed e.g. eow on a
+// Saturday with a Monday to Friday work week. end returns the last day of the period n periods from now.
+func endOf(cal Calendar, today time.Time, end func(n int) time.Time) time.Time {
+	if date := cal.Back(end(0)); !date.Before(today) {
+		return date
+	}
+	return cal.Back(end(1))
+}
+
+func startOfWeek(date time.Time, weekStart time.Weekday) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) - int(weekStart) + 7) % 7))
+}
+
+// field matches the date fields of a task, sched is accepted as an alias of scheduled.
+var field = regexp.MustCompile(`(^|\s)(due|d|scheduled|sched|s):(\S+)`)
+
+// Expand rewrites the date expressions of the due and scheduled fields in the text of a task as ISO dates so the
+// notedown parser can read them. Fields that can't be resolved are left as they are.
+func Expand(text string, now time.Time, weekStart time.Weekday, cal Calendar) string {
+	return field.ReplaceAllStringFunc(text, func(s string) string {
+		match := field.FindStringSubmatch(s)
+		date, ok := Parse(match[3], now, weekStart, cal)
+		if !ok {
+			return s
+		}
+		key := match[2]
+		if key == "sched" {
+			key = "scheduled"
+		}
+		return match[1] + key + ":" + date.Format(Layout)
+	})
+}
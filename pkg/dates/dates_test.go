@@ -0,0 +1,144 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+// now is a Sunday
+var now = time.Date(2026, 10, 18, 15, 4, 5, 0, time.Local)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2026-12-01", "2026-12-01"},
+		{"today", "2026-10-18"},
+		{"tomorrow", "2026-10-19"},
+		{"tom", "2026-10-19"},
+		{"yesterday", "2026-10-17"},
+		{"fri", "2026-10-23"},
+		{"Friday", "2026-10-23"},
+		{"sun", "2026-10-25"},
+		{"+3d", "2026-10-21"},
+		{"2w", "2026-11-01"},
+		{"-1m", "2026-09-18"},
+		{"+1y", "2027-10-18"},
+		{"next-week", "2026-10-19"},
+		{"next-month", "2026-11-01"},
+		{"next-year", "2027-01-01"},
+		{"eow", "2026-10-18"},
+		{"eom", "2026-10-31"},
+		{"eoy", "2026-12-31"},
+		{"nope", ""},
+		{"fr", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			date, ok := Parse(tt.in, now, time.Monday, Calendar{})
+			got := ""
+			if ok {
+				got = date.Format(Layout)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Buy milk due:fri sched:eom p:1", "Buy milk due:2026-10-23 scheduled:2026-10-31 p:1"},
+		{"d:tom Read", "d:2026-10-19 Read"},
+		{"Check s:later", "Check s:later"},
+		{"Post to address:fri", "Post to address:fri"},
+		{"Pay due:2026-11-01", "Pay due:2026-11-01"},
+	}
+	for _, tt := range tests {
+		if got := Expand(tt.in, now, time.Monday, Calendar{}); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseWorkingDays(t *testing.T) {
+	workWeek := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	holidays := []time.Time{time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)} // Wednesday
+	cal := NewCalendar(true, workWeek, holidays)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"today", "2026-10-18"},
+		{"tomorrow", "2026-10-19"},
+		{"+3d", "2026-10-22"},
+		{"+5d", "2026-10-26"},
+		{"yesterday", "2026-10-16"},
+		{"sat", "2026-10-24"},
+		{"2026-10-24", "2026-10-24"},
+		{"+1w", "2026-10-26"},
+		{"eow", "2026-10-23"},
+		{"eom", "2026-10-30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			date, ok := Parse(tt.in, now, time.Monday, cal)
+			if !ok || date.Format(Layout) != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.in, date.Format(Layout), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEndOfPeriod(t *testing.T) {
+	workWeek := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	holidays := []time.Time{time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)} // Friday
+	cal := NewCalendar(true, workWeek, holidays)
+
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 9, 0, 0, 0, time.Local) }
+	tests := []struct {
+		name      string
+		in        string
+		now       time.Time
+		weekStart time.Weekday
+		want      string
+	}{
+		{name: "friday", in: "eow", now: day(16), weekStart: time.Monday, want: "2026-10-16"},
+		{name: "saturday", in: "eow", now: day(17), weekStart: time.Monday, want: "2026-10-23"},
+		{name: "sunday at the end of the week", in: "eow", now: day(18), weekStart: time.Monday, want: "2026-10-23"},
+		{name: "saturday at the end of the week", in: "eow", now: day(17), weekStart: time.Sunday, want: "2026-10-23"},
+		{name: "before a friday holiday", in: "eow", now: day(29), weekStart: time.Monday, want: "2026-10-29"},
+		{name: "on a friday holiday", in: "eow", now: day(30), weekStart: time.Monday, want: "2026-11-06"},
+		{name: "last working day of the month", in: "eom", now: day(29), weekStart: time.Monday, want: "2026-10-29"},
+		{name: "after the last working day of the month", in: "eom", now: day(31), weekStart: time.Monday, want: "2026-11-30"},
+		{name: "after the last working day of the year", in: "eoy", now: time.Date(2028, 12, 30, 9, 0, 0, 0, time.Local), weekStart: time.Monday, want: "2029-12-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, ok := Parse(tt.in, tt.now, tt.weekStart, cal)
+			if !ok || date.Format(Layout) != tt.want {
+				t.Errorf("Parse(%q) on %s = %q, want %q", tt.in, tt.now.Format(Layout), date.Format(Layout), tt.want)
+			}
+		})
+	}
+}
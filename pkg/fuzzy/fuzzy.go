@@ -0,0 +1,92 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fuzzy implements a small subsequence matcher for filtering lists by typed text.
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+)
+
+const (
+	matchScore       = 1
+	consecutiveBonus = 5
+	wordStartBonus   = 10
+)
+
+// Match reports whether every rune of the pattern appears in s in order, ignoring case.
+// The score rewards consecutive runes and runes at the start of words, higher is better.
+func Match(pattern string, s string) (int, bool) {
+	score, _, ok := match(pattern, s)
+	return score, ok
+}
+
+// Indexes returns the indexes of the runes in s matched by the pattern, nil if it doesn't match.
+func Indexes(pattern string, s string) []int {
+	_, indexes, ok := match(pattern, s)
+	if !ok {
+		return nil
+	}
+	return indexes
+}
+
+func match(pattern string, s string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	score, pi, last := 0, 0, -2
+	indexes := make([]int, 0, len(p))
+	runes := []rune(s)
+	for i, r := range runes {
+		if pi == len(p) {
+			break
+		}
+		if unicode.ToLower(r) != unicode.ToLower(p[pi]) {
+			continue
+		}
+		score += matchScore
+		if last == i-1 {
+			score += consecutiveBonus
+		}
+		if i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1]) {
+			score += wordStartBonus
+		}
+		indexes = append(indexes, i)
+		last = i
+		pi++
+	}
+	return score, indexes, pi == len(p)
+}
+
+// Filter returns the indexes of the strings matching the pattern, best match first.
+// Strings with the same score keep their original order, an empty pattern matches everything.
+func Filter(pattern string, strs []string) []int {
+	type result struct{ index, score int }
+	results := make([]result, 0, len(strs))
+	for i, s := range strs {
+		if score, ok := Match(pattern, s); ok {
+			results = append(results, result{i, score})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].score > results[j].score })
+
+	res := make([]int, len(results))
+	for i, r := range results {
+		res[i] = r.index
+	}
+	return res
+}
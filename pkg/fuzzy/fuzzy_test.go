@@ -0,0 +1,69 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		ok      bool
+	}{
+		{"", "anything", true},
+		{"agd", "open the agenda", true},
+		{"AGENDA", "open the agenda", true},
+		{"adn", "add a new task", true},
+		{"xyz", "add a new task", false},
+		{"ksat", "task", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.s, func(t *testing.T) {
+			if _, ok := Match(tt.pattern, tt.s); ok != tt.ok {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.s, ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	strs := []string{
+		"reschedule the selected task",
+		"add a new task",
+		"open the agenda",
+		"add a new project",
+	}
+	if got, want := Filter("at", strs), []int{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter(at) = %v, want %v", got, want)
+	}
+	if got, want := Filter("add p", strs), []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter(add p) = %v, want %v", got, want)
+	}
+	if got, want := Filter("", strs), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	if got, want := Indexes("agd", "open the agenda"), []int{9, 10, 13}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Indexes(agd) = %v, want %v", got, want)
+	}
+	if got := Indexes("xyz", "open the agenda"); got != nil {
+		t.Errorf("Indexes(xyz) = %v, want nil", got)
+	}
+}
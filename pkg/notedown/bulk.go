@@ -0,0 +1,165 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+)
+
+// TaskError records a failed write for a single task of a bulk operation.
+type TaskError struct {
+	Task tasks.Task
+	Err  error
+}
+
+func (e TaskError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.Task.Path(), e.Task.Line(), e.Err)
+}
+
+// writing serialises bulk operations, they run in the background and a Journal collects each into a single change.
+var writing sync.Mutex
+
+// reloadTimeout is how long to wait for the client to pick up a document after writing to it.
+const reloadTimeout = 2 * time.Second
+
+// WriteTasks calls write for each of the tasks, returning an error for each task it failed for.
+//
+// Writes are checked against the version of the document so a document can only be written to once per version.
+// Each task is therefore re-read from the client before it is passed to write, after a write this waits for the client
+// to reload the document. Tasks are written bottom up so lines added or removed by a write never move the tasks above
+// it that are still to be written. If c is a Journal the writes are undone as a single change. This blocks until
+// every task has been written so it shouldn't be called from Update.
+func WriteTasks(c Client, ts []tasks.Task, write func(tasks.Task) error) []TaskError {
+	writing.Lock()
+	defer writing.Unlock()
+	defer batch(c)()
+
+	documents := make(map[string][]tasks.Task)
+	paths := make([]string, 0)
+	for _, t := range ts {
+		if _, ok := documents[t.Path()]; !ok {
+			paths = append(paths, t.Path())
+		}
+		documents[t.Path()] = append(documents[t.Path()], t)
+	}
+
+	errs := make([]TaskError, 0)
+	for _, path := range paths {
+		document := documents[path]
+		sort.SliceStable(document, func(i, j int) bool { return document[i].Line() > document[j].Line() })
+
+		written := "" // version of the document at the last successful write
+		for _, t := range document {
+			current, err := waitForReload(c, t, written)
+			if err != nil {
+				errs = append(errs, TaskError{Task: t, Err: err})
+				continue
+			}
+			if err := write(current); err != nil {
+				errs = append(errs, TaskError{Task: t, Err: err})
+				continue
+			}
+			written = current.Version()
+		}
+	}
+	return errs
+}
+
+// MoveTasks moves the tasks to the end of the document, keeping the order they were in: documents in the order their
+// tasks are given and tasks from the same document in the order of their lines. Tasks already in the document are left
+// where they are.
+func MoveTasks(c Client, ts []tasks.Task, path string) []TaskError {
+	documents := make(map[string][]tasks.Task)
+	paths := make([]string, 0)
+	for _, t := range ts {
+		if t.Path() == path {
+			continue
+		}
+		if _, ok := documents[t.Path()]; !ok {
+			paths = append(paths, t.Path())
+		}
+		documents[t.Path()] = append(documents[t.Path()], t)
+	}
+
+	end, err := c.CountLines(path)
+	if err != nil {
+		errs := make([]TaskError, 0, len(ts))
+		for _, t := range ts {
+			if t.Path() != path {
+				errs = append(errs, TaskError{Task: t, Err: err})
+			}
+		}
+		return errs
+	}
+
+	// Each task is added above the ones moved before it, rather than at the very end, as tasks are written bottom up.
+	// Documents are written in the order they're given so they are reversed to keep them in order too.
+	moving := make([]tasks.Task, 0, len(ts))
+	for i := len(paths) - 1; i >= 0; i-- {
+		moving = append(moving, documents[paths[i]]...)
+	}
+	return WriteTasks(c, moving, func(t tasks.Task) error { return c.MoveTask(t, path, end+1) })
+}
+
+// DeleteProjects deletes each of the projects, returning an error for each project it failed for. If c is a Journal
+// the deletes are undone as a single change.
+func DeleteProjects(c Client, ps []projects.Project) []error {
+	defer batch(c)()
+	errs := make([]error, 0)
+	for _, p := range ps {
+		if err := c.DeleteProject(p); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Path(), err))
+		}
+	}
+	return errs
+}
+
+// batch collects the mutations made through c into a single change until the returned function is called, if c is a
+// Journal.
+func batch(c Client) func() {
+	j, ok := c.(*Journal)
+	if !ok {
+		return func() {}
+	}
+	j.begin()
+	return j.end
+}
+
+// waitForReload waits for the client to load a version of the task's document other than the given one, which may be
+// empty, and returns the task as it is in that version.
+func waitForReload(c Client, t tasks.Task, version string) (tasks.Task, error) {
+	start := time.Now()
+	for {
+		for _, candidate := range c.ListTasks(tasks.FetchTasksForDocument(t.Path())) {
+			if candidate.Line() != t.Line() || candidate.Version() == version {
+				continue
+			}
+			if candidate.Name() != t.Name() {
+				return tasks.Task{}, fmt.Errorf("task has changed to %q", candidate.Name())
+			}
+			return candidate, nil
+		}
+		if time.Since(start) > reloadTimeout {
+			return tasks.Task{}, fmt.Errorf("timed out waiting for %s to be reloaded", t.Path())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"testing"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+)
+
+func TestMoveTasks(t *testing.T) {
+	type ref struct {
+		path string
+		line int
+		name string
+	}
+	tests := []struct {
+		name    string
+		moving  []ref
+		path    string
+		stale   string // b.md is changed to this before moving
+		want    map[string]string
+		wantErr []string // names of the tasks that failed to move
+	}{
+		{
+			name:   "order is kept",
+			moving: []ref{{"a.md", 2, "One"}, {"a.md", 4, "Three"}, {"b.md", 2, "Four"}},
+			path:   "p.md",
+			want: map[string]string{
+				"a.md": "# A\n- [ ] Two\n",
+				"b.md": "# B\n",
+				"p.md": "# P\n- [ ] Existing\n- [ ] One\n- [ ] Three\n- [ ] Four\n",
+			},
+		},
+		{
+			name:   "tasks already in the document are skipped",
+			moving: []ref{{"p.md", 2, "Existing"}, {"a.md", 3, "Two"}},
+			path:   "p.md",
+			want: map[string]string{
+				"a.md": "# A\n- [ ] One\n- [ ] Three\n",
+				"p.md": "# P\n- [ ] Existing\n- [ ] Two\n",
+			},
+		},
+		{
+			name:   "errors are reported for each task",
+			moving: []ref{{"a.md", 2, "One"}, {"b.md", 2, "Four"}},
+			path:   "p.md",
+			stale:  "# B\n- [ ] Four, edited\n",
+			want: map[string]string{
+				"a.md": "# A\n- [ ] Two\n- [ ] Three\n",
+				"b.md": "# B\n- [ ] Four, edited\n",
+				"p.md": "# P\n- [ ] Existing\n- [ ] One\n",
+			},
+			wantErr: []string{"Four"},
+		},
+		{
+			name:    "missing document",
+			moving:  []ref{{"a.md", 2, "One"}, {"b.md", 2, "Four"}},
+			path:    "missing.md",
+			want:    map[string]string{"a.md": "# A\n- [ ] One\n- [ ] Two\n- [ ] Three\n", "b.md": "# B\n- [ ] Four\n"},
+			wantErr: []string{"One", "Four"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, root := testenv.Workspace(t, NewClient, map[string]string{
+				"a.md": "# A\n- [ ] One\n- [ ] Two\n- [ ] Three\n",
+				"b.md": "# B\n- [ ] Four\n",
+				"p.md": "# P\n- [ ] Existing\n",
+			})
+			moving := make([]tasks.Task, 0, len(tt.moving))
+			for _, r := range tt.moving {
+				moving = append(moving, task(t, c, r.path, r.line, r.name))
+			}
+			if tt.stale != "" {
+				testenv.Write(t, root, "b.md", tt.stale)
+			}
+
+			errs := MoveTasks(c, moving, tt.path)
+			failed := make([]string, 0, len(errs))
+			for _, err := range errs {
+				failed = append(failed, err.Task.Name())
+			}
+			if len(failed) != len(tt.wantErr) {
+				t.Fatalf("MoveTasks() = %v, want failures for %q", errs, tt.wantErr)
+			}
+			for i := range failed {
+				if failed[i] != tt.wantErr[i] {
+					t.Errorf("failure %d is for %q, want %q", i, failed[i], tt.wantErr[i])
+				}
+			}
+			for path, want := range tt.want {
+				if got := testenv.Read(t, root, path); got != want {
+					t.Errorf("%s = %q, want %q", path, got, want)
+				}
+			}
+		})
+	}
+}
@@ -39,6 +39,17 @@ type TaskWriter interface {
 	) error
 	UpdateTask(tasks.Task) error
 	DeleteTask(tasks.Task) error
+	MoveTask(tasks.Task, string, int) error
+}
+
+type DocumentReader interface {
+	ListDocuments() []string
+	ListHeadings(string) ([]Heading, error)
+	CountLines(string) (int, error)
+}
+
+type DailyReader interface {
+	ListDailyNotes(daily.Fetcher, ...daily.ListOption) []daily.Daily
 }
 
 type DailyWriter interface {
@@ -60,6 +71,8 @@ type ProjectWriter interface {
 type Client interface {
 	TaskReader
 	TaskWriter
+	DocumentReader
+	DailyReader
 	DailyWriter
 	ProjectReader
 	ProjectWriter
@@ -70,6 +83,9 @@ type client struct {
 	*tasks.TaskClient
 	*daily.DailyClient
 	*projects.ProjectClient
+
+	root  string
+	files *writer.Client
 }
 
 func NewClient(root string) (Client, error) {
@@ -95,6 +111,8 @@ func NewClient(root string) (Client, error) {
 		TaskClient:    tasksClient,
 		DailyClient:   dailyClient,
 		ProjectClient: projectClient,
+		root:          root,
+		files:         write,
 	}, nil
 }
 
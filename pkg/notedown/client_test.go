@@ -0,0 +1,38 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+)
+
+// task waits for the client to load the task on the line of the document with the given name.
+func task(t *testing.T, c Client, path string, line int, name string) tasks.Task {
+	t.Helper()
+	start := time.Now()
+	for time.Since(start) < 2*time.Second {
+		for _, candidate := range c.ListTasks(tasks.FetchTasksForDocument(path)) {
+			if candidate.Line() == line && candidate.Name() == name {
+				return candidate
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no task %q at %s:%d", name, path, line)
+	return tasks.Task{}
+}
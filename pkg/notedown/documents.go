@@ -0,0 +1,189 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+)
+
+// Heading is a markdown heading of a document. Lines are counted from the end of the frontmatter, the same as the
+// lines tasks are written at. End is the last non-blank line of the heading's section, adding a task at End+1 puts it
+// after everything already under the heading.
+type Heading struct {
+	Text  string
+	Level int
+	Line  int
+	End   int
+}
+
+// ListDocuments returns the path of every markdown file in the workspace, skipping hidden directories.
+func (c *client) ListDocuments() []string {
+	res := make([]string, 0)
+	filepath.WalkDir(c.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && path != c.root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".md" {
+			rel, err := filepath.Rel(c.root, path)
+			if err == nil {
+				res = append(res, rel)
+			}
+		}
+		return nil
+	})
+	sort.Strings(res)
+	return res
+}
+
+// ListHeadings returns the headings of the document in the order they appear.
+func (c *client) ListHeadings(path string) ([]Heading, error) {
+	content, _, err := c.read(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := contentLines(content)
+	res := make([]Heading, 0)
+	open := make([]int, 0) // indexes of the headings whose sections haven't ended yet
+	for i, line := range lines {
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level == 0 || level > 6 || !strings.HasPrefix(line[level:], " ") {
+			if strings.TrimSpace(line) != "" {
+				for _, h := range open {
+					res[h].End = i + 1
+				}
+			}
+			continue
+		}
+		// A heading ends the sections of the headings at the same or a deeper level
+		remaining := open[:0]
+		for _, h := range open {
+			if res[h].Level < level {
+				remaining = append(remaining, h)
+			}
+		}
+		open = append(remaining, len(res))
+		res = append(res, Heading{Text: strings.TrimSpace(line[level:]), Level: level, Line: i + 1, End: i + 1})
+	}
+	return res, nil
+}
+
+// CountLines returns the number of lines of the document after its frontmatter, the line of the last task added to
+// its end.
+func (c *client) CountLines(path string) (int, error) {
+	content, _, err := c.read(path)
+	if err != nil {
+		return 0, err
+	}
+	return countLines(content), nil
+}
+
+func countLines(content []byte) int {
+	if len(content) == 0 {
+		return 0
+	}
+	return len(contentLines(content))
+}
+
+// contentLines splits a document into lines, leaving out the frontmatter in the same way the writer does.
+func contentLines(content []byte) []string {
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "---") {
+		for i, line := range lines[1:] {
+			if strings.HasPrefix(line, "---") {
+				return lines[i+2:]
+			}
+		}
+	}
+	return lines
+}
+
+// CreateTask checks tasks added to the middle of a document against the version of the document read just before
+// writing, the tasks client only allows adding them to the start or end.
+func (c *client) CreateTask(path string, line int, name string, status tasks.Status, options ...tasks.TaskOption) error {
+	if line == writer.AT_BEGINNING || line == writer.AT_END {
+		return c.TaskClient.CreateTask(path, line, name, status, options...)
+	}
+	_, checksum, err := c.read(path)
+	if err != nil {
+		return fmt.Errorf("failed to add task: %w", err)
+	}
+	task := tasks.NewTask(tasks.NewIdentifier(path, checksum, line), name, status, options...)
+	if err := c.files.UpdateContent(writer.Document{Path: path, Checksum: checksum}, writer.AddLine(line, task)); err != nil {
+		return fmt.Errorf("failed to add task: %v: %w", task, err)
+	}
+	return nil
+}
+
+// read returns the contents of a document and its checksum, the version the writer checks writes against.
+func (c *client) read(path string) ([]byte, string, error) {
+	content, err := os.ReadFile(filepath.Join(c.root, path))
+	if err != nil {
+		return nil, "", err
+	}
+	return content, fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+// MoveTask moves the task to the line of another document, writer.AT_BEGINNING and writer.AT_END can be used for its
+// start and end. Within a document the task is moved in a single write. Across documents the destination is written
+// first and the task removed from it again if it can't then be removed from its current document, so it is never lost
+// or duplicated.
+func (c *client) MoveTask(t tasks.Task, path string, line int) error {
+	return c.moveTask(t, path, line)
+}
+
+// moveTask moves the task, making the extra mutations to the document it is moved from in the same write as removing it.
+func (c *client) moveTask(t tasks.Task, path string, line int, extra ...writer.LineMutation) error {
+	if path == t.Path() {
+		// The task is removed first so lines after it move up by one
+		if line != writer.AT_END && line > t.Line() {
+			line--
+		}
+		mutations := append([]writer.LineMutation{writer.RemoveLine(t.Line()), writer.AddLine(line, t)}, extra...)
+		if err := c.files.UpdateContent(writer.Document{Path: t.Path(), Checksum: t.Version()}, mutations...); err != nil {
+			return fmt.Errorf("failed to move task: %v: %w", t, err)
+		}
+		return nil
+	}
+
+	before, checksum, err := c.read(path)
+	if err != nil {
+		return fmt.Errorf("failed to move task: %v: %w", t, err)
+	}
+	if err := c.files.UpdateContent(writer.Document{Path: path, Checksum: checksum}, writer.AddLine(line, t)); err != nil {
+		return fmt.Errorf("failed to move task: %v: %w", t, err)
+	}
+	remove := append([]writer.LineMutation{writer.RemoveLine(t.Line())}, extra...)
+	if err := c.files.UpdateContent(writer.Document{Path: t.Path(), Checksum: t.Version()}, remove...); err != nil {
+		// Lines past the end are added at the end and lines before the start at the start
+		added := min(max(line, 1), countLines(before)+1)
+		if restoreErr := c.removeTask(path, added, t); restoreErr != nil {
+			return fmt.Errorf("failed to move task: %v: %w, the task is now in both %s and %s: %v", t, err, t.Path(), path, restoreErr)
+		}
+		return fmt.Errorf("failed to move task: %v: %w", t, err)
+	}
+	return nil
+}
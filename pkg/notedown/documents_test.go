@@ -0,0 +1,211 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+)
+
+const outline = `---
+type: project
+---
+# Top
+Intro
+
+## First
+- [ ] One
+
+### Deep
+- [ ] Two
+## Second
+
+#tag is not a heading
+`
+
+func TestListHeadings(t *testing.T) {
+	c, _ := testenv.Workspace(t, NewClient, map[string]string{"outline.md": outline, "empty.md": ""})
+
+	got, err := c.ListHeadings("outline.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Heading{
+		{Text: "Top", Level: 1, Line: 1, End: 11},
+		{Text: "First", Level: 2, Line: 4, End: 8},
+		{Text: "Deep", Level: 3, Line: 7, End: 8},
+		{Text: "Second", Level: 2, Line: 9, End: 11},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListHeadings() = %+v, want %+v", got, want)
+	}
+
+	if got, err := c.ListHeadings("empty.md"); err != nil || len(got) != 0 {
+		t.Errorf("ListHeadings() of an empty document = %+v, %v", got, err)
+	}
+	if _, err := c.ListHeadings("missing.md"); err == nil {
+		t.Errorf("ListHeadings() of a missing document succeeded")
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "empty", content: "", want: 0},
+		{name: "trailing newline", content: "# A\n- [ ] One\n", want: 2},
+		{name: "no trailing newline", content: "# A\n- [ ] One", want: 2},
+		{name: "trailing blank line", content: "# A\n\n", want: 2},
+		{name: "frontmatter", content: outline, want: 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testenv.Workspace(t, NewClient, map[string]string{"a.md": tt.content})
+			if got, err := c.CountLines("a.md"); err != nil || got != tt.want {
+				t.Errorf("CountLines() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		want    string
+	}{
+		{name: "middle", content: "# A\n- [ ] One\n- [ ] Three\n", line: 3, want: "# A\n- [ ] One\n- [ ] New\n- [ ] Three\n"},
+		{name: "start", content: "# A\n", line: writer.AT_BEGINNING, want: "- [ ] New\n# A\n"},
+		{name: "end", content: "# A\n", line: writer.AT_END, want: "# A\n- [ ] New\n"},
+		{name: "after the frontmatter", content: "---\ntype: project\n---\n# A\n- [ ] One\n", line: 2, want: "---\ntype: project\n---\n# A\n- [ ] New\n- [ ] One\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, root := testenv.Workspace(t, NewClient, map[string]string{"a.md": tt.content})
+			if err := c.CreateTask("a.md", tt.line, "New", tasks.Todo); err != nil {
+				t.Fatal(err)
+			}
+			if got := testenv.Read(t, root, "a.md"); got != tt.want {
+				t.Errorf("a.md = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	const a = "# A\n- [ ] One\n- [ ] Two\n- [ ] Three\n"
+	tests := []struct {
+		name    string
+		from    int // line of the task in a.md
+		task    string
+		path    string
+		line    int // line to move to, -1 for the line after the end of the heading First in outline.md
+		stale   bool
+		wantA   string
+		wantTo  string // content of path if it isn't a.md
+		wantErr bool
+	}{
+		{name: "down", from: 2, task: "One", path: "a.md", line: 4, wantA: "# A\n- [ ] Two\n- [ ] One\n- [ ] Three\n"},
+		{name: "past the end", from: 2, task: "One", path: "a.md", line: writer.AT_END, wantA: "# A\n- [ ] Two\n- [ ] Three\n- [ ] One\n"},
+		{name: "up", from: 4, task: "Three", path: "a.md", line: 2, wantA: "# A\n- [ ] Three\n- [ ] One\n- [ ] Two\n"},
+		{
+			name:   "under a heading after frontmatter",
+			from:   3,
+			task:   "Two",
+			path:   "outline.md",
+			line:   -1,
+			wantA:  "# A\n- [ ] One\n- [ ] Three\n",
+			wantTo: "---\ntype: project\n---\n# Top\nIntro\n\n## First\n- [ ] One\n\n### Deep\n- [ ] Two\n- [ ] Two\n## Second\n\n#tag is not a heading\n",
+		},
+		{
+			name:   "end of another document",
+			from:   2,
+			task:   "One",
+			path:   "b.md",
+			line:   writer.AT_END,
+			wantA:  "# A\n- [ ] Two\n- [ ] Three\n",
+			wantTo: "# B\n- [ ] One\n",
+		},
+		{name: "stale", from: 2, task: "One", path: "a.md", line: 4, stale: true, wantA: a + "edited\n", wantErr: true},
+		{
+			name:    "stale across documents",
+			from:    2,
+			task:    "One",
+			path:    "outline.md",
+			line:    -1,
+			stale:   true,
+			wantA:   a + "edited\n",
+			wantTo:  outline,
+			wantErr: true,
+		},
+		{
+			name:    "stale across documents at the end",
+			from:    2,
+			task:    "One",
+			path:    "b.md",
+			line:    writer.AT_END,
+			stale:   true,
+			wantA:   a + "edited\n",
+			wantTo:  "# B\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, root := testenv.Workspace(t, NewClient, map[string]string{"a.md": a, "b.md": "# B\n", "outline.md": outline})
+			if err := os.Chmod(filepath.Join(root, "outline.md"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			moving := task(t, c, "a.md", tt.from, tt.task)
+			line := tt.line
+			if line == -1 {
+				headings, err := c.ListHeadings(tt.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				line = headings[1].End + 1
+			}
+			if tt.stale {
+				testenv.Write(t, root, "a.md", a+"edited\n")
+			}
+
+			err := c.MoveTask(moving, tt.path, line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := testenv.Read(t, root, "a.md"); got != tt.wantA {
+				t.Errorf("a.md = %q, want %q", got, tt.wantA)
+			}
+			if tt.path != "a.md" {
+				if got := testenv.Read(t, root, tt.path); got != tt.wantTo {
+					t.Errorf("%s = %q, want %q", tt.path, got, tt.wantTo)
+				}
+			}
+			// Writes, including restoring the destination, keep the mode of the file
+			if info, err := os.Stat(filepath.Join(root, "outline.md")); err != nil || info.Mode().Perm() != 0600 {
+				t.Errorf("outline.md mode = %v, %v, want 0600", info.Mode().Perm(), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+)
+
+// Class groups write errors by what can be done about them.
+type Class string
+
+const (
+	Stale      Class = "stale"
+	NotFound   Class = "not found"
+	Exists     Class = "exists"
+	Permission Class = "permission"
+	Invalid    Class = "invalid"
+	Unknown    Class = "error"
+)
+
+// ErrTaskMoved is returned when a task is no longer on the line it was read from.
+var ErrTaskMoved = errors.New("task is no longer on the line it was read from")
+
+// Classify returns the class of a write error and a hint of how to fix it. Retryable reports whether trying again
+// once the document has been re-read may succeed.
+func Classify(err error) (class Class, hint string, retryable bool) {
+	var exists *writer.FileExistsError
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "modified since last read"):
+		return Stale, "the file changed on disk since it was read", true
+	case errors.As(err, &exists):
+		return Exists, "choose a different name or location", false
+	case errors.Is(err, fs.ErrNotExist):
+		return NotFound, "the file has been moved or deleted, pick another location", false
+	case errors.Is(err, fs.ErrPermission):
+		return Permission, "check the permissions of the workspace", true
+	case errors.Is(err, ErrTaskMoved) || strings.Contains(msg, "out of bounds"):
+		return NotFound, "the task is no longer in the file", false
+	case strings.Contains(msg, "newline"):
+		return Invalid, "remove the line break from the text", false
+	}
+	return Unknown, "see the log file for details", true
+}
@@ -0,0 +1,283 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+)
+
+// journalSize is the maximum number of changes that can be undone.
+const journalSize = 100
+
+// Journal wraps a Client, recording the contents of the files touched by each task and project mutation so the
+// mutations can be undone and redone. Replaying a change restores the recorded files directly so it works regardless
+// of how the documents have been reloaded since, as long as no one else has changed them.
+type Journal struct {
+	Client
+	root string
+
+	mu    sync.Mutex
+	undo  []*change
+	redo  []*change
+	batch *change // collects the mutations of a bulk operation into a single change, see WriteTasks
+}
+
+func NewJournal(c Client, root string) *Journal {
+	return &Journal{Client: c, root: root}
+}
+
+// change is a single undoable step, before and after hold the contents of each file it touched.
+type change struct {
+	verb   string
+	noun   string
+	name   string
+	count  int
+	paths  []string
+	before map[string]snapshot
+	after  map[string]snapshot
+}
+
+func newChange() *change {
+	return &change{before: make(map[string]snapshot), after: make(map[string]snapshot)}
+}
+
+// snapshot is the contents of a file, exists is false if the file did not exist.
+type snapshot struct {
+	content []byte
+	exists  bool
+}
+
+func (s snapshot) equal(o snapshot) bool {
+	return s.exists == o.exists && bytes.Equal(s.content, o.content)
+}
+
+func (c *change) String() string {
+	if c.count == 1 {
+		return fmt.Sprintf("%s %s %q", c.verb, c.noun, c.name)
+	}
+	return fmt.Sprintf("%s %d %ss", c.verb, c.count, c.noun)
+}
+
+func (j *Journal) CreateTask(path string, line int, name string, status tasks.Status, options ...tasks.TaskOption) error {
+	return j.record("add", "task", name, func() error {
+		return j.Client.CreateTask(path, line, name, status, options...)
+	}, path)
+}
+
+// toucher is implemented by clients that write to files other than the task's own when updating it.
+type toucher interface {
+	touches(tasks.Task) []string
+}
+
+func (j *Journal) UpdateTask(t tasks.Task) error {
+	paths := []string{t.Path()}
+	if tc, ok := j.Client.(toucher); ok {
+		paths = append(paths, tc.touches(t)...)
+	}
+	return j.record("update", "task", t.Name(), func() error { return j.Client.UpdateTask(t) }, paths...)
+}
+
+func (j *Journal) MoveTask(t tasks.Task, path string, line int) error {
+	paths := []string{t.Path()}
+	if path != t.Path() {
+		paths = append(paths, path)
+	}
+	if tc, ok := j.Client.(toucher); ok {
+		for _, touched := range tc.touches(t) {
+			if touched != path {
+				paths = append(paths, touched)
+			}
+		}
+	}
+	return j.record("move", "task", t.Name(), func() error { return j.Client.MoveTask(t, path, line) }, paths...)
+}
+
+func (j *Journal) DeleteTask(t tasks.Task) error {
+	return j.record("delete", "task", t.Name(), func() error { return j.Client.DeleteTask(t) }, t.Path())
+}
+
+func (j *Journal) CreateProject(path string, name string, status projects.Status, options ...projects.ProjectOption) error {
+	return j.record("add", "project", name, func() error {
+		return j.Client.CreateProject(path, name, status, options...)
+	}, path)
+}
+
+func (j *Journal) UpdateProject(p projects.Project) error {
+	return j.record("update", "project", p.Name(), func() error { return j.Client.UpdateProject(p) }, p.Path())
+}
+
+func (j *Journal) RenameProject(p projects.Project, name string) error {
+	// Mirrors where the project client moves the file to
+	renamed := filepath.Join(filepath.Dir(p.Path()), fmt.Sprintf("%s.md", name))
+	return j.record("rename", "project", p.Name(), func() error { return j.Client.RenameProject(p, name) }, p.Path(), renamed)
+}
+
+func (j *Journal) DeleteProject(p projects.Project) error {
+	return j.record("delete", "project", p.Name(), func() error { return j.Client.DeleteProject(p) }, p.Path())
+}
+
+// record runs the mutation, recording the contents of the given files before and after it.
+func (j *Journal) record(verb, noun, name string, mutate func() error, paths ...string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	before := make(map[string]snapshot)
+	for _, path := range paths {
+		s, err := j.read(path)
+		if err != nil {
+			return err
+		}
+		before[path] = s
+	}
+
+	if err := mutate(); err != nil {
+		return err
+	}
+
+	c := j.batch
+	if c == nil {
+		c = newChange()
+	}
+	if c.count == 0 {
+		c.verb, c.noun, c.name = verb, noun, name
+	}
+	c.count++
+	for _, path := range paths {
+		// Within a batch the first mutation of a file holds its original contents
+		if _, ok := c.before[path]; !ok {
+			c.before[path] = before[path]
+			c.paths = append(c.paths, path)
+		}
+		after, err := j.read(path)
+		if err != nil {
+			return err
+		}
+		c.after[path] = after
+	}
+	if j.batch == nil {
+		j.push(c)
+	}
+	return nil
+}
+
+func (j *Journal) push(c *change) {
+	j.undo = append(j.undo, c)
+	if len(j.undo) > journalSize {
+		j.undo = j.undo[1:]
+	}
+	j.redo = nil
+}
+
+// begin starts collecting mutations into a single change until end is called.
+func (j *Journal) begin() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.batch = newChange()
+}
+
+func (j *Journal) end() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.batch != nil && j.batch.count > 0 {
+		j.push(j.batch)
+	}
+	j.batch = nil
+}
+
+// Undo reverts the most recent change, returning its description.
+func (j *Journal) Undo() (string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.undo) == 0 {
+		return "", errors.New("nothing to undo")
+	}
+	// The change is only taken off the stack once it has been replayed so it can be retried if that fails
+	c := j.undo[len(j.undo)-1]
+	if err := j.replay(c, c.after, c.before); err != nil {
+		return "", fmt.Errorf("failed to undo %s: %w", c, err)
+	}
+	j.undo = j.undo[:len(j.undo)-1]
+	j.redo = append(j.redo, c)
+	return c.String(), nil
+}
+
+// Redo reapplies the most recently undone change, returning its description.
+func (j *Journal) Redo() (string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.redo) == 0 {
+		return "", errors.New("nothing to redo")
+	}
+	c := j.redo[len(j.redo)-1]
+	if err := j.replay(c, c.before, c.after); err != nil {
+		return "", fmt.Errorf("failed to redo %s: %w", c, err)
+	}
+	j.redo = j.redo[:len(j.redo)-1]
+	j.undo = append(j.undo, c)
+	return c.String(), nil
+}
+
+// replay writes the target contents of each file of the change, provided the files are still as expected.
+// Nothing is written if they are not as the files have been edited outside of the journal.
+func (j *Journal) replay(c *change, expected, target map[string]snapshot) error {
+	for _, path := range c.paths {
+		current, err := j.read(path)
+		if err != nil {
+			return err
+		}
+		if !current.equal(expected[path]) {
+			return fmt.Errorf("%s has changed since", path)
+		}
+	}
+	for _, path := range c.paths {
+		if err := j.write(path, target[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *Journal) read(path string) (snapshot, error) {
+	content, err := os.ReadFile(filepath.Join(j.root, path))
+	if os.IsNotExist(err) {
+		return snapshot{}, nil
+	}
+	if err != nil {
+		return snapshot{}, err
+	}
+	return snapshot{content: content, exists: true}, nil
+}
+
+func (j *Journal) write(path string, s snapshot) error {
+	abs := filepath.Join(j.root, path)
+	if !s.exists {
+		if err := os.Remove(abs); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(abs, s.content, 0644)
+}
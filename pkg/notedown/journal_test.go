@@ -0,0 +1,183 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+)
+
+func TestJournalUndoRedo(t *testing.T) {
+	c, root := testenv.Workspace(t, NewClient, map[string]string{"a.md": "# A\n"})
+	j := NewJournal(c, root)
+
+	if _, err := j.Undo(); err == nil {
+		t.Errorf("Undo() with nothing to undo succeeded")
+	}
+	if err := j.CreateTask("a.md", writer.AT_END, "One", tasks.Todo); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.CreateProject("projects/Two.md", "Two", projects.Active); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := j.Undo(); err != nil || got != `add project "Two"` {
+		t.Fatalf("Undo() = %q, %v", got, err)
+	}
+	if s, err := j.read("projects/Two.md"); err != nil || s.exists {
+		t.Errorf("project still exists after undoing the change that created it")
+	}
+	if got := testenv.Read(t, root, "a.md"); got != "# A\n- [ ] One\n" {
+		t.Errorf("a.md after undo = %q", got)
+	}
+
+	if _, err := j.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := testenv.Read(t, root, "a.md"); got != "# A\n" {
+		t.Errorf("a.md after second undo = %q", got)
+	}
+
+	if got, err := j.Redo(); err != nil || got != `add task "One"` {
+		t.Fatalf("Redo() = %q, %v", got, err)
+	}
+	if got := testenv.Read(t, root, "a.md"); got != "# A\n- [ ] One\n" {
+		t.Errorf("a.md after redo = %q", got)
+	}
+
+	// A new change can't be followed by redoing the changes undone before it
+	if err := j.CreateTask("a.md", writer.AT_END, "Three", tasks.Todo); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.Redo(); err == nil {
+		t.Errorf("Redo() after a new change succeeded")
+	}
+}
+
+func TestJournalKeepsChangesThatFailToReplay(t *testing.T) {
+	c, root := testenv.Workspace(t, NewClient, map[string]string{"a.md": "# A\n"})
+	j := NewJournal(c, root)
+	if err := j.CreateTask("a.md", writer.AT_END, "One", tasks.Todo); err != nil {
+		t.Fatal(err)
+	}
+
+	// Edited outside of the journal so undoing would lose the edit
+	written := testenv.Read(t, root, "a.md")
+	testenv.Write(t, root, "a.md", written+"edited\n")
+	if _, err := j.Undo(); err == nil {
+		t.Fatalf("Undo() of a file changed since succeeded")
+	}
+	if got := testenv.Read(t, root, "a.md"); got != written+"edited\n" {
+		t.Errorf("a.md was written by a failed undo: %q", got)
+	}
+
+	// Once the edit is reverted the same change can be undone and then redone
+	testenv.Write(t, root, "a.md", written)
+	if _, err := j.Undo(); err != nil {
+		t.Fatalf("Undo() after reverting the edit = %v", err)
+	}
+	testenv.Write(t, root, "a.md", "# B\n")
+	if _, err := j.Redo(); err == nil {
+		t.Fatalf("Redo() of a file changed since succeeded")
+	}
+	testenv.Write(t, root, "a.md", "# A\n")
+	if _, err := j.Redo(); err != nil {
+		t.Fatalf("Redo() after reverting the edit = %v", err)
+	}
+	if got := testenv.Read(t, root, "a.md"); got != written {
+		t.Errorf("a.md after redo = %q, want %q", got, written)
+	}
+}
+
+func TestJournalSize(t *testing.T) {
+	j := NewJournal(nil, t.TempDir())
+	for i := range journalSize + 5 {
+		c := newChange()
+		c.verb, c.noun, c.name, c.count = "add", "task", fmt.Sprint(i), 1
+		j.push(c)
+	}
+	if len(j.undo) != journalSize {
+		t.Fatalf("journal holds %d changes, want %d", len(j.undo), journalSize)
+	}
+	if got, want := j.undo[0].name, "5"; got != want {
+		t.Errorf("oldest change = %s, want %s", got, want)
+	}
+	if got, want := j.undo[journalSize-1].name, fmt.Sprint(journalSize+4); got != want {
+		t.Errorf("newest change = %s, want %s", got, want)
+	}
+}
+
+func TestJournalBatch(t *testing.T) {
+	c, root := testenv.Workspace(t, NewClient, map[string]string{"a.md": "# A\n", "b.md": "# B\n"})
+	j := NewJournal(c, root)
+
+	// An empty batch isn't a change
+	j.begin()
+	j.end()
+	if len(j.undo) != 0 {
+		t.Fatalf("empty batch recorded %d changes", len(j.undo))
+	}
+
+	j.begin()
+	for _, path := range []string{"a.md", "b.md", "a.md"} {
+		if err := j.CreateTask(path, writer.AT_END, "One", tasks.Todo); err != nil {
+			t.Fatal(err)
+		}
+	}
+	j.end()
+
+	if got, err := j.Undo(); err != nil || got != "add 3 tasks" {
+		t.Fatalf("Undo() = %q, %v", got, err)
+	}
+	if a, b := testenv.Read(t, root, "a.md"), testenv.Read(t, root, "b.md"); a != "# A\n" || b != "# B\n" {
+		t.Errorf("after undo a.md = %q, b.md = %q", a, b)
+	}
+	if _, err := j.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := testenv.Read(t, root, "a.md"); got != "# A\n- [ ] One\n- [ ] One\n" {
+		t.Errorf("a.md after redo = %q", got)
+	}
+}
+
+func TestDeleteProjectsIsASingleChange(t *testing.T) {
+	project := "---\ntype: project\nname: %s\nstatus: active\n---\n"
+	c, root := testenv.Workspace(t, NewClient, map[string]string{
+		"projects/Alpha.md": fmt.Sprintf(project, "Alpha"),
+		"projects/Beta.md":  fmt.Sprintf(project, "Beta"),
+	})
+	j := NewJournal(c, root)
+
+	ps := j.ListProjects(projects.FetchAllProjects())
+	if len(ps) != 2 {
+		t.Fatalf("loaded %d projects, want 2", len(ps))
+	}
+	if errs := DeleteProjects(j, ps); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if got, err := j.Undo(); err != nil || got != "delete 2 projects" {
+		t.Fatalf("Undo() = %q, %v", got, err)
+	}
+	for _, name := range []string{"Alpha", "Beta"} {
+		if got := testenv.Read(t, root, "projects/"+name+".md"); got != fmt.Sprintf(project, name) {
+			t.Errorf("%s after undo = %q", name, got)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/daily"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/teambition/rrule-go"
+)
+
+// Recurrence configures how the next occurrence of a recurring task is created when it is completed.
+type Recurrence struct {
+	// FromCompletion dates the next occurrence from the day the task was completed, otherwise from its due date
+	// (or scheduled date if it has no due date).
+	FromCompletion bool
+
+	// DailyNote adds the next occurrence to the daily note of its date, otherwise to the file of the completed task.
+	DailyNote bool
+}
+
+// Recurring wraps a Client, adding the next occurrence of a recurring task when it is completed. The completed task
+// is updated in place and the next occurrence, with its due and scheduled dates advanced by the every rule, is added
+// to the end of the task's file or the daily note of its date.
+type Recurring struct {
+	Client
+	recurrence Recurrence
+}
+
+func NewRecurring(c Client, r Recurrence) *Recurring {
+	return &Recurring{Client: c, recurrence: r}
+}
+
+// recurrer is implemented by the client to write a completed task together with its next occurrence.
+type recurrer interface {
+	completeRecurring(t tasks.Task, path string, line int, next tasks.Task, nextPath string) error
+}
+
+func (r *Recurring) UpdateTask(t tasks.Task) error {
+	next, ok := r.next(t)
+	if !ok {
+		return r.Client.UpdateTask(t)
+	}
+	return r.complete(t, t.Path(), t.Line(), next)
+}
+
+// MoveTask adds the next occurrence when the task is completed as it's moved. It is added to the file the task is
+// moved from, so completed tasks can be moved out to an archive, unless occurrences are added to daily notes.
+func (r *Recurring) MoveTask(t tasks.Task, path string, line int) error {
+	next, ok := r.next(t)
+	if !ok {
+		return r.Client.MoveTask(t, path, line)
+	}
+	return r.complete(t, path, line, next)
+}
+
+// complete writes the completed task to the line of path along with its next occurrence.
+func (r *Recurring) complete(t tasks.Task, path string, line int, next tasks.Task) error {
+	rc, ok := r.Client.(recurrer)
+	if !ok {
+		return fmt.Errorf("failed to complete task: %v: the client can't add the next occurrence", t)
+	}
+	nextPath := t.Path()
+	if r.recurrence.DailyNote {
+		d, _, err := r.EnsureDaily(date(next), 2*time.Second)
+		if err != nil {
+			return fmt.Errorf("failed to add next occurrence of %v: %w", t, err)
+		}
+		nextPath = d.Path()
+	}
+	return rc.completeRecurring(t, path, line, next, nextPath)
+}
+
+// completeRecurring writes the completed task to the line of path, moving it there if it isn't already, and adds the
+// next occurrence to the end of nextPath. When nextPath is the task's own file both are written at once. Otherwise
+// the next occurrence is added first and removed again if the task can't be written, so a failed write doesn't leave
+// an extra occurrence behind.
+func (c *client) completeRecurring(t tasks.Task, path string, line int, next tasks.Task, nextPath string) error {
+	if nextPath == t.Path() {
+		return c.writeTask(t, path, line, writer.AddLine(writer.AT_END, next))
+	}
+
+	before, checksum, err := c.read(nextPath)
+	if err != nil {
+		return fmt.Errorf("failed to add next occurrence of %v: %w", t, err)
+	}
+	if err := c.files.UpdateContent(writer.Document{Path: nextPath, Checksum: checksum}, writer.AddLine(writer.AT_END, next)); err != nil {
+		return fmt.Errorf("failed to add next occurrence of %v: %w", t, err)
+	}
+
+	if err := c.writeTask(t, path, line); err != nil {
+		if removeErr := c.removeTask(nextPath, countLines(before)+1, next); removeErr != nil {
+			return fmt.Errorf("%w, its next occurrence was still added to %s: %v", err, nextPath, removeErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// writeTask writes the task to the line of path, moving it there if it isn't already. The extra mutations are made to
+// the task's current document in the same write as the task.
+func (c *client) writeTask(t tasks.Task, path string, line int, extra ...writer.LineMutation) error {
+	if path != t.Path() || line != t.Line() {
+		return c.moveTask(t, path, line, extra...)
+	}
+	mutations := append([]writer.LineMutation{writer.UpdateLine(t.Line(), t)}, extra...)
+	if err := c.files.UpdateContent(writer.Document{Path: t.Path(), Checksum: t.Version()}, mutations...); err != nil {
+		return fmt.Errorf("failed to update task: %v: %w", t, err)
+	}
+	return nil
+}
+
+// removeTask removes the task from the line of the document, checking it is still there first.
+func (c *client) removeTask(path string, line int, t tasks.Task) error {
+	content, checksum, err := c.read(path)
+	if err != nil {
+		return err
+	}
+	if lines := contentLines(content); line < 1 || line > len(lines) || lines[line-1] != t.String() {
+		return fmt.Errorf("%s:%d: %w", path, line, ErrTaskMoved)
+	}
+	return c.files.UpdateContent(writer.Document{Path: path, Checksum: checksum}, writer.RemoveLine(line))
+}
+
+// touches returns the daily note the next occurrence of the task is added to, if it isn't the file of the task.
+func (r *Recurring) touches(t tasks.Task) []string {
+	next, ok := r.next(t)
+	if !ok || !r.recurrence.DailyNote {
+		return nil
+	}
+	if path := dailyNote(r, date(next)); path != t.Path() {
+		return []string{path}
+	}
+	return nil
+}
+
+// dailyNote returns the path of the daily note of the date, as found by EnsureDaily or where it creates the note.
+func dailyNote(c DailyReader, date time.Time) string {
+	if notes := c.ListDailyNotes(daily.FetchAllNotes(), daily.WithFilter(daily.FilterByDate(&date, &date))); len(notes) > 0 {
+		return notes[0].Path()
+	}
+	// The daily client doesn't export where it creates notes
+	return filepath.Join("daily", date.Format("2006-01-02")+".md")
+}
+
+// next returns the next occurrence of the task if the update completes a recurring task.
+func (r *Recurring) next(t tasks.Task) (tasks.Task, bool) {
+	if t.Status() != tasks.Done || t.Every() == nil || t.Completed() == nil {
+		return tasks.Task{}, false
+	}
+	for _, current := range r.ListTasks(tasks.FetchTasksForDocument(t.Path())) {
+		if current.Line() == t.Line() && current.Status() == tasks.Done {
+			return tasks.Task{}, false // already completed, this is just an edit
+		}
+	}
+
+	// The due date drives the recurrence, falling back to the scheduled date. The other date keeps its distance to it.
+	var primary *time.Time
+	switch {
+	case t.Due() != nil:
+		primary = t.Due()
+	case t.Scheduled() != nil:
+		primary = t.Scheduled()
+	}
+
+	from := *t.Completed()
+	if primary != nil && !r.recurrence.FromCompletion {
+		from = *primary
+	}
+	nextDate, ok := nextOccurrence(*t.Every(), from)
+	if !ok {
+		return tasks.Task{}, false
+	}
+
+	opts := []tasks.TaskOption{tasks.WithEvery(*t.Every())}
+	if t.Priority() != nil {
+		opts = append(opts, tasks.WithPriority(*t.Priority()))
+	}
+	if primary == nil {
+		opts = append(opts, tasks.WithDue(nextDate))
+	} else {
+		shift := nextDate.Sub(*primary)
+		if t.Due() != nil {
+			opts = append(opts, tasks.WithDue(t.Due().Add(shift)))
+		}
+		if t.Scheduled() != nil {
+			opts = append(opts, tasks.WithScheduled(t.Scheduled().Add(shift)))
+		}
+	}
+	return tasks.NewTask(tasks.NewIdentifier(t.Path(), "", writer.AT_END), t.Name(), tasks.Todo, opts...), true
+}
+
+// date is the earliest of the due and scheduled dates of the task.
+func date(t tasks.Task) time.Time {
+	switch {
+	case t.Scheduled() != nil && (t.Due() == nil || t.Scheduled().Before(*t.Due())):
+		return *t.Scheduled()
+	case t.Due() != nil:
+		return *t.Due()
+	}
+	return time.Time{}
+}
+
+// TaskOptions converts the optional fields of a task back into the options accepted by CreateTask and NewTask.
+func TaskOptions(t tasks.Task) []tasks.TaskOption {
+	opts := make([]tasks.TaskOption, 0)
+	if t.Due() != nil {
+		opts = append(opts, tasks.WithDue(*t.Due()))
+	}
+	if t.Scheduled() != nil {
+		opts = append(opts, tasks.WithScheduled(*t.Scheduled()))
+	}
+	if t.Priority() != nil {
+		opts = append(opts, tasks.WithPriority(*t.Priority()))
+	}
+	if t.Every() != nil {
+		opts = append(opts, tasks.WithEvery(*t.Every()))
+	}
+	if t.Completed() != nil {
+		opts = append(opts, tasks.WithCompleted(*t.Completed()))
+	}
+	return opts
+}
+
+// rule returns the recurrence rule of the every field starting from the given date. The tasks client keeps the rule
+// it parses unexported so it is read from the field and rebuilt with the new start, rather than parsing the text again
+// with a copy of its grammar. Reading it fails if the field is ever renamed or changes type.
+func rule(every tasks.Every, start time.Time) (*rrule.RRule, error) {
+	field := reflect.ValueOf(every).FieldByName("rrule")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(&rrule.RRule{}) || field.IsNil() {
+		return nil, fmt.Errorf("no recurrence rule in %q", every.String())
+	}
+	parsed := (*rrule.RRule)(field.UnsafePointer())
+	opts := parsed.OrigOptions
+	opts.Dtstart = start
+	return rrule.NewRRule(opts)
+}
+
+// nextOccurrence returns the first date after the given date matching the every rule.
+func nextOccurrence(every tasks.Every, after time.Time) (time.Time, bool) {
+	dates := Occurrences(every, after, 1)
+	if len(dates) == 0 {
+		return time.Time{}, false
+	}
+	return dates[0], true
+}
+
+// Occurrences returns up to n dates after the given date matching the every rule, none if the rule never matches.
+func Occurrences(every tasks.Every, after time.Time, n int) []time.Time {
+	after = time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, time.UTC)
+	r, err := rule(every, after)
+	if err != nil {
+		slog.Warn("failed to read recurrence", "every", every.String(), "error", err)
+		return nil
+	}
+	dates := make([]time.Time, 0, n)
+	next := r.Iterator()
+	for len(dates) < n {
+		date, ok := next()
+		if !ok {
+			break
+		}
+		if date.After(after) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notedown
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestOccurrences(t *testing.T) {
+	friday := day(2024, 6, 7)
+	tests := []struct {
+		every string
+		after time.Time
+		want  []time.Time
+	}{
+		{every: "day", after: friday, want: []time.Time{day(2024, 6, 8), day(2024, 6, 9), day(2024, 6, 10)}},
+		{every: "week", after: friday, want: []time.Time{day(2024, 6, 14), day(2024, 6, 21), day(2024, 6, 28)}},
+		{every: "month", after: day(2024, 1, 31), want: []time.Time{day(2024, 3, 31), day(2024, 5, 31), day(2024, 7, 31)}},
+		{every: "year", after: friday, want: []time.Time{day(2025, 6, 7), day(2026, 6, 7), day(2027, 6, 7)}},
+		{every: "weekday", after: friday, want: []time.Time{day(2024, 6, 10), day(2024, 6, 11), day(2024, 6, 12)}},
+		{every: "weekend", after: friday, want: []time.Time{day(2024, 6, 8), day(2024, 6, 15), day(2024, 6, 22)}},
+		{every: "mon wed", after: friday, want: []time.Time{day(2024, 6, 10), day(2024, 6, 12), day(2024, 6, 17)}},
+		{every: "2 weeks", after: friday, want: []time.Time{day(2024, 6, 21), day(2024, 7, 5), day(2024, 7, 19)}},
+		{every: "3 days", after: friday, want: []time.Time{day(2024, 6, 10), day(2024, 6, 13), day(2024, 6, 16)}},
+		{every: "15th", after: friday, want: []time.Time{day(2024, 6, 15), day(2024, 7, 15), day(2024, 8, 15)}},
+		{every: "1st 15th", after: friday, want: []time.Time{day(2024, 6, 15), day(2024, 7, 1), day(2024, 7, 15)}},
+		{every: "jan", after: friday, want: []time.Time{day(2025, 1, 1), day(2026, 1, 1), day(2027, 1, 1)}},
+		{every: "25 dec", after: friday, want: []time.Time{day(2024, 12, 25), day(2025, 12, 25), day(2026, 12, 25)}},
+		{every: "30 feb", after: friday, want: []time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.every, func(t *testing.T) {
+			every, err := tasks.NewEvery(tt.every)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The time of day is ignored
+			got := Occurrences(every, tt.after.Add(15*time.Hour), 3)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no rule", func(t *testing.T) {
+		if got := Occurrences(tasks.Every{}, day(2024, 6, 7), 3); len(got) != 0 {
+			t.Errorf("Occurrences() = %v, want none", got)
+		}
+	})
+}
+
+func TestRecurring(t *testing.T) {
+	completed := day(2024, 6, 10)
+	daily := "daily/2024-06-12.md"
+
+	tests := []struct {
+		name       string
+		recurrence Recurrence
+		content    string // a.md, the task to complete is on line 2
+		task       string // name of the task to complete
+		move       string // file to move the task to as it is completed
+		stale      bool   // a.md changes before the task is written
+		want       string // a.md after completing the task
+		wantDaily  string // last line of the daily note, empty if it shouldn't exist
+		wantErr    bool
+	}{
+		{
+			name: "from the due date",
+			task: "Water plants", content: "# A\n- [ ] Water plants due:2024-06-05 every:week\n- [ ] Other\n",
+			want: "# A\n- [x] Water plants due:2024-06-05 every:week completed:2024-06-10\n- [ ] Other\n- [ ] Water plants due:2024-06-12 every:week\n",
+		},
+		{
+			name:       "from the completion date",
+			recurrence: Recurrence{FromCompletion: true},
+			task:       "Water plants",
+			content:    "# A\n- [ ] Water plants due:2024-06-05 every:week\n",
+			want:       "# A\n- [x] Water plants due:2024-06-05 every:week completed:2024-06-10\n- [ ] Water plants due:2024-06-17 every:week\n",
+		},
+		{
+			name: "scheduled date keeps its distance to the due date",
+			task: "Pay rent", content: "# A\n- [ ] Pay rent due:2024-06-05 scheduled:2024-06-03 every:month priority:1\n",
+			want: "# A\n- [x] Pay rent due:2024-06-05 scheduled:2024-06-03 priority:1 every:month completed:2024-06-10\n- [ ] Pay rent due:2024-07-05 scheduled:2024-07-03 priority:1 every:month\n",
+		},
+		{
+			name: "scheduled date without a due date",
+			task: "Stretch", content: "# A\n- [ ] Stretch scheduled:2024-06-09 every:day\n",
+			want: "# A\n- [x] Stretch scheduled:2024-06-09 every:day completed:2024-06-10\n- [ ] Stretch scheduled:2024-06-10 every:day\n",
+		},
+		{
+			name: "undated tasks recur from the completion date",
+			task: "Call home", content: "# A\n- [ ] Call home every:2 days\n",
+			want: "# A\n- [x] Call home every:2 days completed:2024-06-10\n- [ ] Call home due:2024-06-12 every:2 days\n",
+		},
+		{
+			name: "stale",
+			task: "Water plants", content: "# A\n- [ ] Water plants due:2024-06-05 every:week\n",
+			stale:   true,
+			want:    "# A\n- [ ] Water plants due:2024-06-05 every:week\nedited\n",
+			wantErr: true,
+		},
+		{
+			name: "moved to another file",
+			task: "Water plants", content: "# A\n- [ ] Water plants due:2024-06-05 every:week\n",
+			move: "b.md",
+			want: "# A\n- [ ] Water plants due:2024-06-12 every:week\n",
+		},
+		{
+			name:       "daily note",
+			recurrence: Recurrence{DailyNote: true},
+			task:       "Water plants",
+			content:    "# A\n- [ ] Water plants due:2024-06-05 every:week\n",
+			want:       "# A\n- [x] Water plants due:2024-06-05 every:week completed:2024-06-10\n",
+			wantDaily:  "- [ ] Water plants due:2024-06-12 every:week",
+		},
+		{
+			name:       "daily note from the completion date",
+			recurrence: Recurrence{FromCompletion: true, DailyNote: true},
+			task:       "Water plants",
+			content:    "# A\n- [ ] Water plants due:2024-06-03 every:2 days\n",
+			want:       "# A\n- [x] Water plants due:2024-06-03 every:2 days completed:2024-06-10\n",
+			wantDaily:  "- [ ] Water plants due:2024-06-12 every:2 days",
+		},
+		{
+			name:       "daily note removed from when the task can't be completed",
+			recurrence: Recurrence{DailyNote: true},
+			task:       "Water plants",
+			content:    "# A\n- [ ] Water plants due:2024-06-05 every:week\n",
+			stale:      true,
+			want:       "# A\n- [ ] Water plants due:2024-06-05 every:week\nedited\n",
+			wantDaily:  "",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, root := testenv.Workspace(t, NewClient, map[string]string{"a.md": tt.content, "b.md": "# B\n"})
+			r := NewRecurring(c, tt.recurrence)
+
+			original := task(t, c, "a.md", 2, tt.task)
+			if tt.stale {
+				testenv.Write(t, root, "a.md", tt.content+"edited\n")
+			}
+			done := tasks.NewTaskFromTask(original, tasks.WithStatus(tasks.Done, completed))
+			var err error
+			if tt.move != "" {
+				err = r.MoveTask(done, tt.move, writer.AT_END)
+			} else {
+				err = r.UpdateTask(done)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := testenv.Read(t, root, "a.md"); got != tt.want {
+				t.Errorf("a.md = %q, want %q", got, tt.want)
+			}
+			if tt.move != "" {
+				if got, want := testenv.Read(t, root, tt.move), "# B\n"+done.String()+"\n"; got != want {
+					t.Errorf("%s = %q, want %q", tt.move, got, want)
+				}
+			}
+			note, err := os.ReadFile(filepath.Join(root, daily))
+			switch {
+			case tt.recurrence.DailyNote && err != nil:
+				t.Errorf("%s not created: %v", daily, err)
+			case tt.wantDaily == "" && strings.Contains(string(note), tt.task):
+				t.Errorf("%s = %q, want no occurrence", daily, note)
+			case tt.wantDaily != "":
+				lines := strings.Split(strings.TrimSpace(string(note)), "\n")
+				if got := lines[len(lines)-1]; got != tt.wantDaily {
+					t.Errorf("%s ends with %q, want %q", daily, got, tt.wantDaily)
+				}
+			}
+		})
+	}
+}
+
+func TestDailyNote(t *testing.T) {
+	c, _ := testenv.Workspace(t, NewClient, map[string]string{"journal/2024-06-12.md": "---\ntype: daily\n---\n"})
+
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{date: day(2024, 6, 12), want: "journal/2024-06-12.md"},
+		{date: day(2024, 6, 13), want: "daily/2024-06-13.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.Format(time.DateOnly), func(t *testing.T) {
+			if got := dailyNote(c, tt.date); got != tt.want {
+				t.Errorf("dailyNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
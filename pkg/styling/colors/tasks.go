@@ -30,6 +30,8 @@ func Task(theme themes.Theme, status tasks.Status) (bg, fg lipgloss.Color, err e
 		return theme.Panel, theme.Red, nil
 	case tasks.Doing:
 		return theme.Panel, theme.Green, nil
+	case tasks.Done, tasks.Abandoned:
+		return theme.Panel, theme.TextFaint, nil
 	default:
 		return theme.Panel, theme.Text, fmt.Errorf("unexpected task status %v", status)
 	}
@@ -43,6 +45,8 @@ func TaskSelected(theme themes.Theme, status tasks.Status) (bg, fg lipgloss.Colo
 		return theme.Red, theme.TextCursor, nil
 	case tasks.Doing:
 		return theme.Green, theme.TextCursor, nil
+	case tasks.Done, tasks.Abandoned:
+		return theme.TextFaint, theme.TextCursor, nil
 	default:
 		return theme.Text, theme.TextCursor, fmt.Errorf("unexpected task status %v", status)
 	}
@@ -21,6 +21,9 @@ import (
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
 )
 
+// Marked replaces the status icon of items that are part of a multi-selection.
+const Marked = "󰄲"
+
 func Task(status tasks.Status) string {
 	switch status {
 	case tasks.Todo:
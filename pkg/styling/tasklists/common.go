@@ -20,6 +20,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/styling/icons"
 )
 
 var (
@@ -36,6 +38,13 @@ func dateAfter(a, b time.Time) bool {
 	return a.Truncate(time.Hour * 24).After(b.Truncate(time.Hour * 24))
 }
 
+func icon(task tasks.Task, state groupedlist.ItemState) string {
+	if state.Marked {
+		return icons.Marked
+	}
+	return icons.Task(task.Status())
+}
+
 func priority(task tasks.Task) string {
 	if task.Priority() != nil {
 		return fmt.Sprintf(" %d", *task.Priority())
@@ -0,0 +1,58 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasklists
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/fuzzy"
+)
+
+const (
+	pathPrefix     = "path:"
+	priorityPrefix = "p:"
+)
+
+// Matcher filters tasks by fuzzy matching their name. Tokens prefixed with path: match part of the path of the task
+// and tokens prefixed with p: match its priority, e.g. "report path:work p:1".
+func Matcher(task tasks.Task, query string) bool {
+	text, values := groupedlist.ParseQuery(query, pathPrefix, priorityPrefix)
+	if path, ok := values[pathPrefix]; ok && !strings.Contains(strings.ToLower(task.Path()), strings.ToLower(path)) {
+		return false
+	}
+	if p, ok := values[priorityPrefix]; ok && p != "" {
+		if task.Priority() == nil || strconv.Itoa(*task.Priority()) != p {
+			return false
+		}
+	}
+	_, ok := fuzzy.Match(text, task.Name())
+	return ok
+}
+
+// Identity identifies tasks by their location as tasks with the same text are common e.g. recurring tasks.
+func Identity(task tasks.Task) string {
+	return fmt.Sprintf("%s:%d", task.Path(), task.Line())
+}
+
+// highlight renders the name of a task with the runes matched by the query emphasised.
+func highlight(name string, query string, base lipgloss.Style) string {
+	text, _ := groupedlist.ParseQuery(query, pathPrefix, priorityPrefix)
+	return groupedlist.Highlight(name, text, base, base.Bold(true).Underline(true))
+}
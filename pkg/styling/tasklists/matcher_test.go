@@ -0,0 +1,55 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasklists
+
+import (
+	"testing"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+)
+
+func TestMatcher(t *testing.T) {
+	prioritised := tasks.NewTask(tasks.NewIdentifier("projects/Work.md", "", 3), "Write report", tasks.Todo, tasks.WithPriority(1))
+	unprioritised := tasks.NewTask(tasks.NewIdentifier("daily/2024-06-03.md", "", 5), "Write report", tasks.Todo)
+
+	tests := []struct {
+		name  string
+		query string
+		task  tasks.Task
+		want  bool
+	}{
+		{name: "empty", query: "", task: prioritised, want: true},
+		{name: "name", query: "wrt rep", task: prioritised, want: true},
+		{name: "name mismatch", query: "email", task: prioritised, want: false},
+		{name: "path", query: "path:work", task: prioritised, want: true},
+		{name: "path is a substring not fuzzy", query: "path:wrk", task: prioritised, want: false},
+		{name: "path mismatch", query: "path:daily", task: prioritised, want: false},
+		{name: "path and name", query: "report path:projects", task: prioritised, want: true},
+		{name: "path and name mismatch", query: "email path:projects", task: prioritised, want: false},
+		{name: "empty path matches all", query: "path:", task: unprioritised, want: true},
+		{name: "priority", query: "p:1", task: prioritised, want: true},
+		{name: "priority mismatch", query: "p:2", task: prioritised, want: false},
+		{name: "priority without one set", query: "p:1", task: unprioritised, want: false},
+		{name: "empty priority matches all", query: "p:", task: unprioritised, want: true},
+		{name: "all", query: "report path:work p:1", task: prioritised, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matcher(tt.task, tt.query); got != tt.want {
+				t.Errorf("Matcher(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -22,7 +22,6 @@ import (
 	"github.com/mattn/go-runewidth"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
 	"github.com/notedownorg/task/pkg/components/groupedlist"
-	"github.com/notedownorg/task/pkg/styling/icons"
 	"github.com/notedownorg/task/pkg/themes"
 )
 
@@ -49,31 +48,33 @@ func CompletedRenderers(theme themes.Theme) groupedlist.Renderers[tasks.Task] {
 				"",
 			)
 		},
-		Item: func(task tasks.Task, width int) string {
+		Item: func(task tasks.Task, state groupedlist.ItemState) string {
+			width := state.Width
 			fields := []string{
-				icons.Task(task.Status()),
+				icon(task, state),
 				s().Render(runewidth.Truncate(task.Name(), width-paddingHorizontal*2-3, "…")), // need to account for icon and padding
 			}
 
 			switch task.Status() {
 			case tasks.Done, tasks.Abandoned:
 				base := s().Background(theme.Panel).Foreground(theme.TextFaint)
-				return s().Width(width).Background(theme.Panel).Padding(0, paddingHorizontal).Render(base.Render(fields[0]+"  ") + base.Strikethrough(true).Render(fields[1]))
+				return s().Width(width).Background(theme.Panel).Padding(0, paddingHorizontal).Render(base.Render(fields[0]+"  ") + highlight(fields[1], state.Query, base.Strikethrough(true)))
 			}
 
 			slog.Warn("unexpected task status", "status", task.Status())
 			return ""
 		},
-		Selected: func(task tasks.Task, width int) string {
+		Selected: func(task tasks.Task, state groupedlist.ItemState) string {
+			width := state.Width
 			fields := []string{
-				icons.Task(task.Status()),
+				icon(task, state),
 				lipgloss.NewStyle().Render(runewidth.Truncate(task.Name(), width-paddingHorizontal*2-3, "…")), // need to account for icon and padding
 			}
 
 			switch task.Status() {
 			case tasks.Done, tasks.Abandoned:
 				base := s().Background(theme.TextFaint).Foreground(theme.TextCursor)
-				return s().Width(width).Padding(0, paddingHorizontal).Background(theme.TextFaint).Render(base.Render(fields[0]+"  ") + base.Strikethrough(true).Render(fields[1]))
+				return s().Width(width).Padding(0, paddingHorizontal).Background(theme.TextFaint).Render(base.Render(fields[0]+"  ") + highlight(fields[1], state.Query, base.Strikethrough(true)))
 			}
 
 			slog.Warn("unexpected task status", "status", task.Status())
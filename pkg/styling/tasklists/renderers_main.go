@@ -24,7 +24,6 @@ import (
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
 	"github.com/notedownorg/task/pkg/components/groupedlist"
 	"github.com/notedownorg/task/pkg/styling/colors"
-	"github.com/notedownorg/task/pkg/styling/icons"
 	"github.com/notedownorg/task/pkg/themes"
 )
 
@@ -67,7 +66,8 @@ func MainRenderers(theme themes.Theme, dateRetriever func() time.Time) groupedli
 			)
 		},
 
-		Item: func(task tasks.Task, width int) string {
+		Item: func(task tasks.Task, state groupedlist.ItemState) string {
+			width := state.Width
 			bg, fg, err := colors.Task(theme, task.Status())
 			if err != nil {
 				slog.Warn("unexpected task status", "status", task.Status())
@@ -77,7 +77,7 @@ func MainRenderers(theme themes.Theme, dateRetriever func() time.Time) groupedli
 			right := buildRight(false)(theme, task, dateRetriever, bg)
 
 			remainingSpace := width - w(right) - 2*paddingHorizontal
-			left := buildLeft(false)(theme, task, remainingSpace, bg)
+			left := buildLeft(false)(theme, task, remainingSpace, bg, fg, state)
 
 			middlePadding := width - w(left) - w(right) - 2*paddingHorizontal
 			middle := s().Background(bg).PaddingRight(middlePadding).Render("") // fill out the rest of the space
@@ -85,7 +85,8 @@ func MainRenderers(theme themes.Theme, dateRetriever func() time.Time) groupedli
 			return s().Width(width).Padding(0, paddingHorizontal).Background(bg).Foreground(fg).Render(left + middle + right)
 		},
 
-		Selected: func(task tasks.Task, width int) string {
+		Selected: func(task tasks.Task, state groupedlist.ItemState) string {
+			width := state.Width
 			bg, fg, err := colors.TaskSelected(theme, task.Status())
 			if err != nil {
 				slog.Warn("unexpected task status", "status", task.Status())
@@ -95,7 +96,7 @@ func MainRenderers(theme themes.Theme, dateRetriever func() time.Time) groupedli
 			right := buildRight(true)(theme, task, dateRetriever, bg)
 
 			remainingSpace := width - w(right) - 2*paddingHorizontal
-			left := buildLeft(true)(theme, task, remainingSpace, bg)
+			left := buildLeft(true)(theme, task, remainingSpace, bg, fg, state)
 
 			middlePadding := width - w(left) - w(right) - 2*paddingHorizontal
 			middle := s().Background(bg).PaddingRight(middlePadding).Render("") // fill out the rest of the space
@@ -147,17 +148,20 @@ func datePrinter(want, curr time.Time) string {
 }
 
 // See https://github.com/charmbracelet/lipgloss/issues/144 for why we need to pass bg
-func buildLeft(selected bool) func(theme themes.Theme, task tasks.Task, remainingSpace int, bg lipgloss.Color) string {
-	return func(theme themes.Theme, task tasks.Task, remainingSpace int, bg lipgloss.Color) string {
+func buildLeft(selected bool) func(theme themes.Theme, task tasks.Task, remainingSpace int, bg lipgloss.Color, fg lipgloss.Color, state groupedlist.ItemState) string {
+	return func(theme themes.Theme, task tasks.Task, remainingSpace int, bg lipgloss.Color, fg lipgloss.Color, state groupedlist.ItemState) string {
 		res := make([]string, 0)
 
-		i := icons.Task(task.Status())
+		i := icon(task, state)
 		res = append(res, i, "  ")
 
 		e := every(task)
 
 		textWidth := remainingSpace - w(i) - w(e)
 		text := runewidth.Truncate(task.Name(), textWidth, "…")
+		if state.Query != "" {
+			text = highlight(text, state.Query, s().Background(bg).Foreground(fg))
+		}
 		res = append(res, text)
 
 		if e != "" {
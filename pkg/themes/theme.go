@@ -41,3 +41,8 @@ type Theme struct {
 	RedSoft  lipgloss.Color
 	BlueSoft lipgloss.Color
 }
+
+// Themes maps the names that can be used to select a theme in the config file to the themes themselves.
+var Themes = map[string]Theme{
+	"catppuccin-mocha": CatpuccinMocha,
+}
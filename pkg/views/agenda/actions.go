@@ -0,0 +1,123 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenda
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/views/confirm"
+	"github.com/notedownorg/task/pkg/views/kanban"
+	"github.com/notedownorg/task/pkg/views/taskeditor"
+	"github.com/notedownorg/task/pkg/views/taskreschedule"
+)
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		// Internal to the agenda view
+		{Binding: m.keyMap.TogglePanels, Run: func() (tea.Model, tea.Cmd) { m.togglePanels(); return nil, nil }},
+		{Binding: m.keyMap.NextDay, Run: func() (tea.Model, tea.Cmd) { m.updateDate(m.date.AddDate(0, 0, 1)); return nil, nil }},
+		{Binding: m.keyMap.PrevDay, Run: func() (tea.Model, tea.Cmd) { m.updateDate(m.date.AddDate(0, 0, -1)); return nil, nil }},
+		{Binding: m.keyMap.ResetDate, Run: m.resetDate},
+		{Binding: m.keyMap.CursorUp, Run: func() (tea.Model, tea.Cmd) { m.moveUp(1); return nil, nil }},
+		{Binding: m.keyMap.CursorDown, Run: func() (tea.Model, tea.Cmd) { m.moveDown(1); return nil, nil }},
+
+		// Navigation
+		{Binding: m.keyMap.AddTask, Run: m.addTask},
+		{Binding: m.keyMap.EditTask, Run: m.editTask},
+		{Binding: m.keyMap.OpenBoard, Run: m.openBoard},
+
+		// Other Task operations
+		{Binding: m.keyMap.RescheduleTask, Run: m.rescheduleTask},
+		{Binding: m.keyMap.CompleteTask, Run: m.setStatus(tasks.Done, "complete")},
+		{Binding: m.keyMap.StartTask, Run: m.setStatus(tasks.Doing, "start")},
+		{Binding: m.keyMap.BlockTask, Run: m.setStatus(tasks.Blocked, "block")},
+		{Binding: m.keyMap.TodoTask, Run: m.setStatus(tasks.Todo, "reset")},
+		{Binding: m.keyMap.AbandonTask, Run: m.setStatus(tasks.Abandoned, "abandon")},
+		{Binding: m.keyMap.DeleteTask, Run: m.deleteTasks},
+	}
+}
+
+func (m *Model) resetDate() (tea.Model, tea.Cmd) {
+	m.date = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.UTC)
+	return nil, nil
+}
+
+func (m *Model) addTask() (tea.Model, tea.Cmd) {
+	return m.ctx.Navigate(taskeditor.New(
+		m.ctx,
+		m.nd,
+		taskeditor.WithAddToDaily(tasks.Todo, fmt.Sprintf(" due:%s", m.date.Format("2006-01-02")), m.date),
+	))
+}
+
+func (m *Model) editTask() (tea.Model, tea.Cmd) {
+	if selected := m.selectedTask(); selected != nil {
+		return m.ctx.Navigate(taskeditor.New(
+			m.ctx,
+			m.nd,
+			taskeditor.WithEdit(*selected, m.date),
+		))
+	}
+	return nil, nil
+}
+
+// openBoard shows the week containing the agenda's date on a kanban board.
+func (m *Model) openBoard() (tea.Model, tea.Cmd) {
+	start := m.date.AddDate(0, 0, -((int(m.date.Weekday()) - int(m.ctx.WeekStart()) + 7) % 7))
+	return m.ctx.Navigate(kanban.New(m.ctx, m.nd, kanban.WithDateRange(start, start.AddDate(0, 0, 6))))
+}
+
+func (m *Model) rescheduleTask() (tea.Model, tea.Cmd) {
+	if targets := m.targets(); len(targets) > 0 {
+		return m.ctx.Navigate(taskreschedule.New(m.ctx, m.nd, targets...))
+	}
+	return nil, nil
+}
+
+// setStatus returns an action that sets the status of the selected tasks, verb describes the change in error messages.
+func (m *Model) setStatus(status tasks.Status, verb string) func() (tea.Model, tea.Cmd) {
+	return func() (tea.Model, tea.Cmd) {
+		targets, now := m.targets(), m.ctx.Now()
+		m.clearMarks()
+		return nil, m.footer.Write(verb, len(targets), func() []notedown.TaskError {
+			return notedown.WriteTasks(m.nd, targets, func(t tasks.Task) error {
+				return m.nd.UpdateTask(tasks.NewTaskFromTask(t, tasks.WithStatus(status, now)))
+			})
+		})
+	}
+}
+
+func (m *Model) deleteTasks() (tea.Model, tea.Cmd) {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Name()
+	}
+	return confirm.Guard(m.ctx, m.nd, confirm.DeleteTask, confirm.Describe("delete", "task", names...), func() (tea.Model, tea.Cmd) {
+		m.clearMarks()
+		return nil, m.footer.Write("delete", len(targets), func() []notedown.TaskError {
+			return notedown.WriteTasks(m.nd, targets, m.nd.DeleteTask)
+		})
+	})
+}
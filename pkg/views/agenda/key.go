@@ -14,7 +14,11 @@
 
 package agenda
 
-import "github.com/charmbracelet/bubbles/v2/key"
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/context"
+)
 
 type KeyMap struct {
 	TogglePanels key.Binding
@@ -31,6 +35,12 @@ type KeyMap struct {
 	DeleteTask     key.Binding
 	RescheduleTask key.Binding
 	CompleteTask   key.Binding
+	StartTask      key.Binding
+	BlockTask      key.Binding
+	TodoTask       key.Binding
+	AbandonTask    key.Binding
+
+	OpenBoard key.Binding
 }
 
 var DefaultKeyMap = KeyMap{
@@ -68,14 +78,72 @@ var DefaultKeyMap = KeyMap{
 	),
 	DeleteTask: key.NewBinding(
 		key.WithKeys("d"),
-		key.WithHelp("d", "delete the selected task"),
+		key.WithHelp("d", "delete the selected tasks"),
 	),
 	RescheduleTask: key.NewBinding(
 		key.WithKeys("r"),
-		key.WithHelp("r", "reschedule the selected task"),
+		key.WithHelp("r", "reschedule the selected tasks"),
 	),
 	CompleteTask: key.NewBinding(
 		key.WithKeys("x"),
-		key.WithHelp("x", "complete the selected task"),
+		key.WithHelp("x", "complete the selected tasks"),
+	),
+	StartTask: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "start the selected tasks"),
+	),
+	BlockTask: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "block the selected tasks"),
+	),
+	TodoTask: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "move the selected tasks back to todo"),
+	),
+	AbandonTask: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "abandon (cancel) the selected tasks"),
+	),
+	OpenBoard: key.NewBinding(
+		key.WithKeys("K", "shift+k"),
+		key.WithHelp("K", "open this week's tasks on a board"),
 	),
 }
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"TogglePanels":   &k.TogglePanels,
+		"NextDay":        &k.NextDay,
+		"PrevDay":        &k.PrevDay,
+		"ResetDate":      &k.ResetDate,
+		"CursorUp":       &k.CursorUp,
+		"CursorDown":     &k.CursorDown,
+		"AddTask":        &k.AddTask,
+		"EditTask":       &k.EditTask,
+		"DeleteTask":     &k.DeleteTask,
+		"RescheduleTask": &k.RescheduleTask,
+		"CompleteTask":   &k.CompleteTask,
+		"StartTask":      &k.StartTask,
+		"BlockTask":      &k.BlockTask,
+		"TodoTask":       &k.TodoTask,
+		"AbandonTask":    &k.AbandonTask,
+		"OpenBoard":      &k.OpenBoard,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.AddTask, k.EditTask, k.CompleteTask, k.RescheduleTask, k.TogglePanels}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.CursorUp, k.CursorDown, k.TogglePanels, groupedlist.DefaultKeyMap.Filter},
+		{k.NextDay, k.PrevDay, k.ResetDate},
+		{k.AddTask, k.EditTask, k.DeleteTask, k.RescheduleTask, k.OpenBoard},
+		{k.CompleteTask, k.StartTask, k.BlockTask, k.TodoTask, k.AbandonTask},
+		{groupedlist.DefaultKeyMap.Mark, groupedlist.DefaultKeyMap.MarkRange, groupedlist.DefaultKeyMap.MarkGroup},
+	}
+}
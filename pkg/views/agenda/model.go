@@ -15,10 +15,9 @@
 package agenda
 
 import (
-	"fmt"
 	"time"
 
-	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/help"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
 	"github.com/notedownorg/task/pkg/components/groupedlist"
@@ -27,8 +26,6 @@ import (
 	"github.com/notedownorg/task/pkg/listeners"
 	"github.com/notedownorg/task/pkg/notedown"
 	"github.com/notedownorg/task/pkg/styling/tasklists"
-	"github.com/notedownorg/task/pkg/views/taskeditor"
-	"github.com/notedownorg/task/pkg/views/taskreschedule"
 )
 
 const (
@@ -36,16 +33,24 @@ const (
 )
 
 func HandleNew(nd notedown.Client) context.GlobalKeyHandler {
-	return func(ctx *context.ProgramContext, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-		key := msg.Key()
-		if key.Mod == tea.ModCtrl && key.Code == 'a' {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.Agenda,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
 			return ctx.Navigate(New(ctx, nd))
-		}
-		return nil, nil
+		},
+	}
+}
+
+type Option func(*Model)
+
+// WithDate opens the agenda on the given date rather than today.
+func WithDate(date time.Time) Option {
+	return func(m *Model) {
+		m.date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	}
 }
 
-func New(ctx *context.ProgramContext, nd notedown.Client) *Model {
+func New(ctx *context.ProgramContext, nd notedown.Client, opts ...Option) *Model {
 	date := time.Date(ctx.Now().Year(), ctx.Now().Month(), ctx.Now().Day(), 0, 0, 0, 0, ctx.Now().Location())
 	m := &Model{
 		ctx: ctx,
@@ -54,10 +59,13 @@ func New(ctx *context.ProgramContext, nd notedown.Client) *Model {
 		keyMap: DefaultKeyMap,
 		date:   date,
 
-		completed: groupedlist.New(groupedlist.WithRenderers(tasklists.CompletedRenderers(ctx.Theme))),
-		footer:    statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd),
+		completed: groupedlist.New(groupedlist.WithRenderers(tasklists.CompletedRenderers(ctx.Theme)), groupedlist.WithMatcher(tasklists.Matcher), groupedlist.WithIdentity(tasklists.Identity)),
+		footer:    statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
-	m.tasklist = groupedlist.New(groupedlist.WithRenderers(tasklists.MainRenderers(ctx.Theme, func() time.Time { return m.date }))).Focus()
+	m.tasklist = groupedlist.New(groupedlist.WithRenderers(tasklists.MainRenderers(ctx.Theme, func() time.Time { return m.date })), groupedlist.WithMatcher(tasklists.Matcher), groupedlist.WithIdentity(tasklists.Identity)).Focus()
 	m.updateTasks()
 	return m
 }
@@ -74,6 +82,11 @@ type Model struct {
 	footer    *statusbar.Model
 }
 
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
 func (m *Model) Init() (tea.Model, tea.Cmd) {
 	_, cmd := m.ctx.Init()
 	return m, cmd
@@ -83,58 +96,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch {
-
-		// Internal to the agenda view
-		case key.Matches(msg, m.keyMap.TogglePanels):
-			m.togglePanels()
-		case key.Matches(msg, m.keyMap.NextDay):
-			m.updateDate(m.date.AddDate(0, 0, 1))
-		case key.Matches(msg, m.keyMap.PrevDay):
-			m.updateDate(m.date.AddDate(0, 0, -1))
-		case key.Matches(msg, m.keyMap.ResetDate):
-			m.date = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.UTC)
-		case key.Matches(msg, m.keyMap.CursorUp):
-			m.moveUp(1)
-		case key.Matches(msg, m.keyMap.CursorDown):
-			m.moveDown(1)
-
-		// Navigation
-		case key.Matches(msg, m.keyMap.AddTask):
-			return m.ctx.Navigate(taskeditor.New(
-				m.ctx,
-				m.nd,
-				taskeditor.WithAddToDaily(tasks.Todo, fmt.Sprintf(" due:%s", m.date.Format("2006-01-02")), m.date),
-			))
-		case key.Matches(msg, m.keyMap.EditTask):
-			if selected := m.selectedTask(); selected != nil {
-				return m.ctx.Navigate(taskeditor.New(
-					m.ctx,
-					m.nd,
-					taskeditor.WithEdit(*selected, m.date),
-				))
-			}
-
-		// Other Task operations
-		case key.Matches(msg, m.keyMap.RescheduleTask):
-			if selected := m.selectedTask(); selected != nil {
-				return m.ctx.Navigate(taskreschedule.New(m.ctx, m.nd, selected))
-			}
-
-		case key.Matches(msg, m.keyMap.CompleteTask):
-			if selected := m.selectedTask(); selected != nil {
-				t := tasks.NewTaskFromTask(*selected, tasks.WithStatus(tasks.Done, m.ctx.Now()))
-				if err := m.nd.UpdateTask(t); err != nil {
-					m.footer.SetMessage(fmt.Sprintf("error completing task: %v", err), time.Now().Add(10*time.Second), m.ctx.Theme.Red)
-				}
-			}
-
-		case key.Matches(msg, m.keyMap.DeleteTask):
-			if selected := m.selectedTask(); selected != nil {
-				if err := m.nd.DeleteTask(*selected); err != nil {
-					m.footer.SetMessage(fmt.Sprintf("error deleting task: %v", err), time.Now().Add(10*time.Second), m.ctx.Theme.Red)
-				}
+		// Filtering is handled by the lists themselves, if they consume the key press there is nothing left to do
+		if handled, command := m.tasklist.Update(msg); handled {
+			return m, command
+		}
+		if handled, command := m.completed.Update(msg); handled {
+			return m, command
+		}
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
 			}
+			cmd = command
 		}
 	}
 
@@ -159,6 +133,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 }
 
+// targets are the tasks that bulk operations apply to in the focused list.
+func (m *Model) targets() []tasks.Task {
+	if m.completed.Focused() {
+		return m.completed.Targets()
+	}
+	return m.tasklist.Targets()
+}
+
+func (m *Model) clearMarks() {
+	m.tasklist.ClearMarks()
+	m.completed.ClearMarks()
+}
+
 func (m *Model) selectedTask() *tasks.Task {
 	if m.completed.Focused() {
 		return m.completed.Selected()
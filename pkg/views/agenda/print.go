@@ -0,0 +1,42 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenda
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/styling/tasklists"
+	"github.com/notedownorg/task/pkg/themes"
+)
+
+// Render renders the agenda for a date as a static block of text using the same groups and renderers as the view.
+// It's intended for printing the agenda outside of the TUI, e.g. to stdout.
+func Render(nd notedown.Client, theme themes.Theme, date time.Time, now time.Time, width int) string {
+	main, completed := groups(nd, date)
+
+	tasklist := groupedlist.New(groupedlist.WithRenderers(tasklists.MainRenderers(theme, func() time.Time { return date }))).Width(width)
+	tasklist.SetGroups(main)
+
+	done := groupedlist.New(groupedlist.WithRenderers(tasklists.CompletedRenderers(theme))).Width(width)
+	done.SetGroups(completed)
+
+	header := s().Bold(true).Render(fmt.Sprintf("%s (%s)", humanizeDate(date, now), date.Format("2006-01-02")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", tasklist.Render(), done.Render())
+}
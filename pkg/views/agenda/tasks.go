@@ -23,8 +23,15 @@ import (
 )
 
 func (m *Model) updateTasks() {
-	due := due(m.nd, m.date)
-	done := done(m.nd, m.date)
+	main, completed := groups(m.nd, m.date)
+	m.tasklist.SetGroups(main)
+	m.completed.SetGroups(completed)
+}
+
+// groups splits the agenda for the given date into the groups for the main list and the completed list.
+func groups(nd notedown.Client, date time.Time) ([]groupedlist.Group[tasks.Task], []groupedlist.Group[tasks.Task]) {
+	due := Due(nd, date)
+	done := Done(nd, date)
 
 	doing := groupedlist.Group[tasks.Task]{
 		Name:  statusName[tasks.Doing],
@@ -39,11 +46,11 @@ func (m *Model) updateTasks() {
 		Items: tasks.WithFilter(tasks.FilterByStatus(tasks.Blocked))(due),
 	}
 
-	m.tasklist.SetGroups([]groupedlist.Group[tasks.Task]{doing, todo, blocked})
-	m.completed.SetGroups([]groupedlist.Group[tasks.Task]{{Name: "Completed", Items: done}})
+	return []groupedlist.Group[tasks.Task]{doing, todo, blocked}, []groupedlist.Group[tasks.Task]{{Name: "Completed", Items: done}}
 }
 
-func due(nd notedown.Client, date time.Time) []tasks.Task {
+// Due returns the open tasks that are due or scheduled on or before the date.
+func Due(nd notedown.Client, date time.Time) []tasks.Task {
 	// Tasks are in UTC, so we need to use that.
 	next := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
 
@@ -65,7 +72,8 @@ func due(nd notedown.Client, date time.Time) []tasks.Task {
 	)
 }
 
-func done(nd notedown.Client, date time.Time) []tasks.Task {
+// Done returns the tasks that were completed on the date.
+func Done(nd notedown.Client, date time.Time) []tasks.Task {
 	// Tasks are in UTC, so we need to use that.
 	prev := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 	next := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
@@ -76,9 +76,9 @@ func TestDue(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := len(due(nd, tt.date))
+			got := len(Due(nd, tt.date))
 			if got != tt.want {
-				t.Errorf("due() = %v, want %v, all %v", got, tt.want, len(nd.ListTasks(tasks.FetchAllTasks())))
+				t.Errorf("Due() = %v, want %v, all %v", got, tt.want, len(nd.ListTasks(tasks.FetchAllTasks())))
 			}
 		})
 	}
@@ -127,9 +127,9 @@ func TestDone(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := len(done(nd, tt.date))
+			got := len(Done(nd, tt.date))
 			if got != tt.want {
-				t.Errorf("done() = %v, want %v, all %v", got, tt.want, len(nd.ListTasks(tasks.FetchAllTasks())))
+				t.Errorf("Done() = %v, want %v, all %v", got, tt.want, len(nd.ListTasks(tasks.FetchAllTasks())))
 			}
 		})
 	}
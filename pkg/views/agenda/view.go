@@ -32,7 +32,7 @@ func (m *Model) View() string {
 	horizontalPadding := 2
 	verticalPadding := 1
 
-	header := fmt.Sprintf("← %v →", humanizeDate(m.date, time.Now()))
+	header := m.header()
 
 	footer := m.footer.
 		Width(m.ctx.ScreenWidth - horizontalPadding*2).
@@ -54,6 +54,19 @@ func (m *Model) View() string {
 	return lipgloss.NewStyle().Padding(verticalPadding, horizontalPadding).Render(panel)
 }
 
+// header shows the date of the agenda, marking weekends and holidays as days off.
+func (m *Model) header() string {
+	header := fmt.Sprintf("← %v →", humanizeDate(m.date, time.Now()))
+	cal := m.ctx.Calendar()
+	switch {
+	case cal.IsHoliday(m.date):
+		return header + s().Foreground(m.ctx.Theme.Red).Render("  holiday")
+	case cal.IsWeekend(m.date):
+		return header + s().Foreground(m.ctx.Theme.RedSoft).Render("  weekend")
+	}
+	return header
+}
+
 func humanizeDate(date time.Time, relativeTo time.Time) string {
 	rel := time.Date(relativeTo.Year(), relativeTo.Month(), relativeTo.Day(), 0, 0, 0, 0, time.UTC)
 	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
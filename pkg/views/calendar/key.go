@@ -0,0 +1,96 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+type KeyMap struct {
+	PrevDay  key.Binding
+	NextDay  key.Binding
+	PrevWeek key.Binding
+	NextWeek key.Binding
+
+	PrevMonth key.Binding
+	NextMonth key.Binding
+	ResetDate key.Binding
+
+	OpenDay key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	PrevDay: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("←/h", "previous day"),
+	),
+	NextDay: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("→/l", "next day"),
+	),
+	PrevWeek: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑/k", "previous week"),
+	),
+	NextWeek: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓/j", "next week"),
+	),
+	PrevMonth: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous month"),
+	),
+	NextMonth: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next month"),
+	),
+	ResetDate: key.NewBinding(
+		key.WithKeys("home", "0"),
+		key.WithHelp("home/0", "go to today"),
+	),
+	OpenDay: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open the agenda for the selected day"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"PrevDay":   &k.PrevDay,
+		"NextDay":   &k.NextDay,
+		"PrevWeek":  &k.PrevWeek,
+		"NextWeek":  &k.NextWeek,
+		"PrevMonth": &k.PrevMonth,
+		"NextMonth": &k.NextMonth,
+		"ResetDate": &k.ResetDate,
+		"OpenDay":   &k.OpenDay,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevMonth, k.NextMonth, k.ResetDate, k.OpenDay}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.PrevDay, k.NextDay, k.PrevWeek, k.NextWeek},
+		{k.PrevMonth, k.NextMonth, k.ResetDate},
+		{k.OpenDay},
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/listeners"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/views/agenda"
+)
+
+const (
+	view = "calendar"
+)
+
+func HandleNew(nd notedown.Client) context.GlobalKeyHandler {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.Calendar,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
+			return ctx.Navigate(New(ctx, nd))
+		},
+	}
+}
+
+func New(ctx *context.ProgramContext, nd notedown.Client) *Model {
+	m := &Model{
+		ctx:    ctx,
+		nd:     nd,
+		keyMap: DefaultKeyMap,
+		footer: statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
+	}
+	m.resetDate()
+	return m
+}
+
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	keyMap KeyMap
+
+	date   time.Time        // the selected day, the month containing it is shown
+	counts map[int]dayCount // counts for each day of the grid keyed by the offset from the first day of the grid
+
+	footer *statusbar.Model
+}
+
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	_, cmd := m.ctx.Init()
+	return m, cmd
+}
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	move := func(years, months, days int) func() (tea.Model, tea.Cmd) {
+		return func() (tea.Model, tea.Cmd) { m.updateDate(m.date.AddDate(years, months, days)); return nil, nil }
+	}
+	return []context.Action{
+		{Binding: m.keyMap.PrevDay, Run: move(0, 0, -1)},
+		{Binding: m.keyMap.NextDay, Run: move(0, 0, 1)},
+		{Binding: m.keyMap.PrevWeek, Run: move(0, 0, -7)},
+		{Binding: m.keyMap.NextWeek, Run: move(0, 0, 7)},
+		{Binding: m.keyMap.PrevMonth, Run: func() (tea.Model, tea.Cmd) { m.updateDate(addMonths(m.date, -1)); return nil, nil }},
+		{Binding: m.keyMap.NextMonth, Run: func() (tea.Model, tea.Cmd) { m.updateDate(addMonths(m.date, 1)); return nil, nil }},
+		{Binding: m.keyMap.ResetDate, Run: func() (tea.Model, tea.Cmd) { m.resetDate(); return nil, nil }},
+		{Binding: m.keyMap.OpenDay, Run: func() (tea.Model, tea.Cmd) {
+			return m.ctx.Navigate(agenda.New(m.ctx, m.nd, agenda.WithDate(m.date)))
+		}},
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		}
+	}
+
+	// If the task client has emitted an event, refresh the counts
+	if _, ok := msg.(listeners.TaskEvent); ok {
+		m.updateCounts()
+	}
+
+	// If we're being navigated back to, refresh the counts
+	if _, ok := msg.(context.NavigationEvent); ok {
+		m.updateCounts()
+	}
+
+	// Handle program level key presses and events
+	model, command := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, tea.Batch(command, cmd)
+	}
+	cmd = tea.Batch(cmd, command)
+	return m, cmd
+}
+
+// today is the current date at midnight UTC as that is how task dates are stored.
+func (m *Model) today() time.Time {
+	now := m.ctx.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (m *Model) resetDate() {
+	m.updateDate(m.today())
+}
+
+func (m *Model) updateDate(date time.Time) {
+	month := m.date.Month() != date.Month() || m.date.Year() != date.Year()
+	m.date = date
+	if month || m.counts == nil {
+		m.updateCounts()
+	}
+}
+
+// gridStart is the first day shown on the grid, the start of the week containing the first of the month.
+func (m *Model) gridStart() time.Time {
+	first := time.Date(m.date.Year(), m.date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(first.Weekday()) - int(m.ctx.WeekStart()) + 7) % 7
+	return first.AddDate(0, 0, -offset)
+}
+
+// weeks is the number of rows needed to show every day of the month.
+func (m *Model) weeks() int {
+	last := time.Date(m.date.Year(), m.date.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	return int(last.Sub(m.gridStart())/(24*time.Hour))/7 + 1
+}
+
+// addMonths moves the date by whole months, clamping the day to the end of shorter months.
+func addMonths(date time.Time, n int) time.Time {
+	last := time.Date(date.Year(), date.Month()+time.Month(n)+1, 0, 0, 0, 0, 0, date.Location())
+	return time.Date(last.Year(), last.Month(), min(date.Day(), last.Day()), 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/themes"
+)
+
+// newTestModel returns a calendar for a workspace with the given files and a fixed clock.
+func newTestModel(t *testing.T, files map[string]string, now time.Time, weekStart time.Weekday) *Model {
+	t.Helper()
+	nd, _ := testenv.Workspace(t, notedown.NewClient, files)
+	return testenv.View(now, func(ctx *context.ProgramContext) *Model { return New(ctx, nd) }, context.WithWeekStart(weekStart))
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGrid(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		weekStart time.Weekday
+		start     time.Time // first day of the grid
+		end       time.Time // last day of the grid
+		weeks     int
+	}{
+		{
+			name:      "leading and trailing days",
+			date:      date(2024, 6, 5),
+			weekStart: time.Sunday,
+			start:     date(2024, 5, 26),
+			end:       date(2024, 7, 6),
+			weeks:     6,
+		},
+		{
+			name:      "leading days only",
+			date:      date(2024, 6, 5),
+			weekStart: time.Monday,
+			start:     date(2024, 5, 27),
+			end:       date(2024, 6, 30),
+			weeks:     5,
+		},
+		{
+			name:      "month fills the grid",
+			date:      date(2026, 2, 14),
+			weekStart: time.Sunday,
+			start:     date(2026, 2, 1),
+			end:       date(2026, 2, 28),
+			weeks:     4,
+		},
+		{
+			name:      "first of the month at the end of the week",
+			date:      date(2026, 2, 14),
+			weekStart: time.Monday,
+			start:     date(2026, 1, 26),
+			end:       date(2026, 3, 1),
+			weeks:     5,
+		},
+		{
+			name:      "last of the month starts a row",
+			date:      date(2024, 9, 30),
+			weekStart: time.Monday,
+			start:     date(2024, 8, 26),
+			end:       date(2024, 10, 6),
+			weeks:     6,
+		},
+		{
+			name:      "midweek start",
+			date:      date(2024, 6, 5),
+			weekStart: time.Wednesday,
+			start:     date(2024, 5, 29),
+			end:       date(2024, 7, 2),
+			weeks:     5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, nil, tt.date, tt.weekStart)
+			start, weeks := m.gridStart(), m.weeks()
+			if !start.Equal(tt.start) {
+				t.Errorf("gridStart() = %s, want %s", start.Format(time.DateOnly), tt.start.Format(time.DateOnly))
+			}
+			if start.Weekday() != tt.weekStart {
+				t.Errorf("grid starts on a %s, want %s", start.Weekday(), tt.weekStart)
+			}
+			if weeks != tt.weeks {
+				t.Errorf("weeks() = %d, want %d", weeks, tt.weeks)
+			}
+			if end := start.AddDate(0, 0, weeks*7-1); !end.Equal(tt.end) {
+				t.Errorf("grid ends %s, want %s", end.Format(time.DateOnly), tt.end.Format(time.DateOnly))
+			}
+		})
+	}
+}
+
+// The month of Wednesday 2024-06-05 on a grid from Sunday 2024-05-26 to Saturday 2024-07-06.
+const month = `# Month
+- [ ] Overdue due:2024-05-20
+- [ ] Due today due:2024-06-05
+- [ ] Scheduled before due due:2024-06-20 scheduled:2024-06-10
+- [/] Due on a trailing day due:2024-07-03
+- [ ] Due after the grid due:2024-07-07
+- [x] Done on a leading day completed:2024-05-28
+- [x] Done today completed:2024-06-05
+- [a] Abandoned due:2024-06-12
+`
+
+func TestCounts(t *testing.T) {
+	m := newTestModel(t, map[string]string{"month.md": month}, time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC), time.Sunday)
+
+	want := map[time.Time]dayCount{
+		date(2024, 5, 28): {done: 1},
+		date(2024, 6, 5):  {open: 2, done: 1},
+		date(2024, 6, 10): {open: 1},
+		date(2024, 7, 3):  {open: 1},
+	}
+	start := m.gridStart()
+	if len(m.counts) != m.weeks()*7 {
+		t.Fatalf("counted %d days, want %d", len(m.counts), m.weeks()*7)
+	}
+	for day, got := range m.counts {
+		date := start.AddDate(0, 0, day)
+		if got != want[date] {
+			t.Errorf("%s = %+v, want %+v", date.Format(time.DateOnly), got, want[date])
+		}
+	}
+}
+
+func TestDensity(t *testing.T) {
+	theme := themes.CatpuccinMocha
+	tests := []struct {
+		open int
+		bg   lipgloss.Color
+		fg   lipgloss.Color
+	}{
+		{open: 0, bg: theme.Panel, fg: theme.Text},
+		{open: 1, bg: theme.Green, fg: theme.TextCursor},
+		{open: 2, bg: theme.Green, fg: theme.TextCursor},
+		{open: 3, bg: theme.Yellow, fg: theme.TextCursor},
+		{open: 4, bg: theme.Yellow, fg: theme.TextCursor},
+		{open: 5, bg: theme.Orange, fg: theme.TextCursor},
+		{open: 6, bg: theme.Orange, fg: theme.TextCursor},
+		{open: 7, bg: theme.Red, fg: theme.TextCursor},
+		{open: 20, bg: theme.Red, fg: theme.TextCursor},
+	}
+	for _, tt := range tests {
+		bg, fg := density(theme, tt.open)
+		if bg != tt.bg || fg != tt.fg {
+			t.Errorf("density(%d) = %s on %s, want %s on %s", tt.open, fg, bg, tt.fg, tt.bg)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/views/agenda"
+)
+
+// dayCount is the number of tasks that fall on a day.
+type dayCount struct {
+	open int
+	done int
+}
+
+// updateCounts counts the tasks for each day of the grid. Open tasks are counted on the earliest of their due and
+// scheduled dates, the first day they appear in the agenda, or on today if that has passed as with the week view.
+func (m *Model) updateCounts() {
+	start := m.gridStart()
+	n := m.weeks() * 7
+	m.counts = make(map[int]dayCount, n)
+
+	today := m.today()
+	for _, t := range agenda.Due(m.nd, start.AddDate(0, 0, n-1)) {
+		date := first(t)
+		if date.Before(today) {
+			date = today
+		}
+		if day := int(date.Sub(start) / (24 * time.Hour)); day >= 0 {
+			c := m.counts[day]
+			c.open++
+			m.counts[day] = c
+		}
+	}
+	for day := 0; day < n; day++ {
+		c := m.counts[day]
+		c.done = len(agenda.Done(m.nd, start.AddDate(0, 0, day)))
+		m.counts[day] = c
+	}
+}
+
+// first returns the earliest of the task's due and scheduled dates at midnight UTC.
+func first(t tasks.Task) time.Time {
+	var res *time.Time
+	for _, date := range []*time.Time{t.Due(), t.Scheduled()} {
+		if date != nil && (res == nil || date.Before(*res)) {
+			res = date
+		}
+	}
+	return time.Date(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0, time.UTC)
+}
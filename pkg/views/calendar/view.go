@@ -0,0 +1,116 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/styling/icons"
+	"github.com/notedownorg/task/pkg/themes"
+)
+
+var (
+	s = lipgloss.NewStyle
+	h = lipgloss.Height
+)
+
+// density returns the colours of a day with the given number of open tasks, scaling from the panel colour for a
+// free day up to red for an overloaded one.
+func density(theme themes.Theme, open int) (bg lipgloss.Color, fg lipgloss.Color) {
+	switch {
+	case open == 0:
+		return theme.Panel, theme.Text
+	case open <= 2:
+		return theme.Green, theme.TextCursor
+	case open <= 4:
+		return theme.Yellow, theme.TextCursor
+	case open <= 6:
+		return theme.Orange, theme.TextCursor
+	default:
+		return theme.Red, theme.TextCursor
+	}
+}
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalPadding := 1
+	gap := " "
+
+	header := fmt.Sprintf("← %s →", m.date.Format("January 2006"))
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth - horizontalPadding*2).
+		View()
+
+	weeks := m.weeks()
+	width := (m.ctx.ScreenWidth - horizontalPadding*2 - 6) / 7                   // -6 for the gaps between days
+	height := m.ctx.ScreenHeight - h(footer) - h(header) - verticalPadding*2 - 3 // -3 for the gaps and weekday names
+	cellHeight := max(height/weeks-1, 2)                                         // -1 for the gap between weeks
+
+	names := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((int(m.ctx.WeekStart()) + i) % 7)
+		names = append(names, s().Width(width).Align(lipgloss.Center).Foreground(m.ctx.Theme.TextFaint).Render(day.String()[:3]))
+	}
+
+	rows := []string{strings.Join(names, gap)}
+	start := m.gridStart()
+	for week := 0; week < weeks; week++ {
+		cells := make([]string, 0, 13)
+		for i := 0; i < 7; i++ {
+			if i > 0 {
+				cells = append(cells, gap)
+			}
+			day := week*7 + i
+			cells = append(cells, m.renderCell(start.AddDate(0, 0, day), m.counts[day], width, cellHeight))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...), "")
+	}
+
+	grid := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	panel := lipgloss.JoinVertical(lipgloss.Top, header, "", grid, footer)
+
+	return s().Padding(verticalPadding, horizontalPadding).Render(panel)
+}
+
+// renderCell renders the day number, highlighted when selected, above the task counts coloured by their density.
+func (m *Model) renderCell(date time.Time, count dayCount, width int, height int) string {
+	theme := m.ctx.Theme
+	bg, fg := density(theme, count.open)
+	if date.Month() != m.date.Month() {
+		bg, fg = theme.Panel, theme.TextFaint
+	}
+
+	label := fmt.Sprintf("%d", date.Day())
+	if date.Equal(m.today()) {
+		label += " today"
+	}
+	title := s().Width(width).Padding(0, 1).Background(bg).Foreground(fg).Bold(true)
+	if date.Equal(m.date) {
+		title = title.Background(theme.Text).Foreground(theme.TextCursor)
+	}
+
+	counts := ""
+	if count.open > 0 || count.done > 0 {
+		counts = fmt.Sprintf("%s %d  %s %d", icons.Task(tasks.Todo), count.open, icons.Task(tasks.Done), count.done)
+	}
+	body := s().Width(width).Height(height-1).Padding(0, 1).Background(bg).Foreground(fg).Render(counts)
+
+	return lipgloss.JoinVertical(lipgloss.Left, title.Render(label), body)
+}
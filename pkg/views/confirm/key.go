@@ -0,0 +1,55 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+type KeyMap struct {
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	Confirm: key.NewBinding(
+		key.WithKeys("y", "enter"),
+		key.WithHelp("y/enter", "confirm"),
+	),
+	// Going back (esc by default) also cancels, it is a global binding so it isn't repeated here
+	Cancel: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "cancel"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"Confirm": &k.Confirm,
+		"Cancel":  &k.Cancel,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Confirm, k.Cancel}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Confirm, k.Cancel}}
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+// The actions that can be configured to require confirmation.
+const (
+	DeleteTask    = "delete_task"
+	DeleteProject = "delete_project"
+)
+
+// Actions lists every action that can require confirmation.
+var Actions = []string{DeleteTask, DeleteProject}
+
+// Model is a dialog asking the user to confirm a destructive action before it is run.
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	description string
+	run         func() (tea.Model, tea.Cmd)
+
+	keyMap KeyMap
+
+	footer *statusbar.Model
+}
+
+// New asks the user to confirm the action described, e.g. "delete 3 tasks", run is called once confirmed.
+func New(ctx *context.ProgramContext, nd notedown.Client, description string, run func() (tea.Model, tea.Cmd)) *Model {
+	return &Model{
+		ctx:         ctx,
+		nd:          nd,
+		description: description,
+		run:         run,
+		keyMap:      DefaultKeyMap,
+		footer:      statusbar.New(ctx, statusbar.NewMode(description, statusbar.ActionDelete), nd).SetHelp(DefaultKeyMap),
+	}
+}
+
+// Guard calls run straight away unless the action must be confirmed, in which case the dialog is opened first.
+func Guard(ctx *context.ProgramContext, nd notedown.Client, action string, description string, run func() (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if !ctx.Confirms(action) {
+		return run()
+	}
+	return ctx.Navigate(New(ctx, nd, description, run))
+}
+
+// Describe returns a description of an action on one or more named items e.g. `delete task "Buy milk"`.
+func Describe(verb string, noun string, names ...string) string {
+	if len(names) == 1 {
+		return fmt.Sprintf("%s %s %q", verb, noun, names[0])
+	}
+	return fmt.Sprintf("%s %d %ss", verb, len(names), noun)
+}
+
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		{Binding: m.keyMap.Confirm, Run: m.confirm},
+		{Binding: m.keyMap.Cancel, Run: func() (tea.Model, tea.Cmd) { return m.ctx.Back(), nil }},
+	}
+}
+
+// confirm returns to the previous view before running the action so it can report back in its own status bar.
+func (m *Model) confirm() (tea.Model, tea.Cmd) {
+	view := m.ctx.Back()
+	model, cmd := m.run()
+	if model == nil {
+		return view, cmd
+	}
+	return model, cmd
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	// Handle view level key presses
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		}
+	}
+
+	// Handle program level key presses and events
+	model, command := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, tea.Batch(command, cmd)
+	}
+	cmd = tea.Batch(cmd, command)
+	return m, cmd
+}
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalMargin := 1
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth-horizontalPadding*2).
+		Margin(verticalMargin, 0).
+		View()
+
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint)
+	hint := faint.Render(fmt.Sprintf("%s %s  %s %s",
+		m.keyMap.Confirm.Help().Key, m.keyMap.Confirm.Help().Desc,
+		m.keyMap.Cancel.Help().Key, m.keyMap.Cancel.Help().Desc,
+	))
+
+	top := lipgloss.NewStyle().
+		Margin(1, 3).
+		Render(
+			lipgloss.JoinVertical(lipgloss.Center,
+				fmt.Sprintf("Are you sure you want to %s?", m.description),
+				"",
+				hint,
+			),
+		)
+
+	border := lipgloss.RoundedBorder()
+	var b strings.Builder
+	str := "Confirm"
+	for i := len(str) + 2; i <= lipgloss.Width(top); i++ {
+		b.WriteString(lipgloss.RoundedBorder().Top)
+	}
+	b.WriteString(str)
+	border.Top = b.String()
+
+	form := lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(m.ctx.Theme.Red).
+		Render(top)
+
+	width := m.ctx.ScreenWidth - horizontalPadding*2
+	height := m.ctx.ScreenHeight - lipgloss.Height(footer)
+
+	dialog := lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, form)
+
+	panel := lipgloss.JoinVertical(lipgloss.Top, dialog, footer)
+
+	return lipgloss.NewStyle().Padding(0, horizontalPadding).Render(panel)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/views/taskeditor"
+)
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		{Binding: m.keyMap.CursorUp, Run: func() (tea.Model, tea.Cmd) { m.columns[m.focus].MoveUp(1); return nil, nil }},
+		{Binding: m.keyMap.CursorDown, Run: func() (tea.Model, tea.Cmd) { m.columns[m.focus].MoveDown(1); return nil, nil }},
+		{Binding: m.keyMap.PrevColumn, Run: func() (tea.Model, tea.Cmd) { m.focusColumn(m.focus - 1); return nil, nil }},
+		{Binding: m.keyMap.NextColumn, Run: func() (tea.Model, tea.Cmd) { m.focusColumn(m.focus + 1); return nil, nil }},
+		{Binding: m.keyMap.MoveLeft, Run: m.move(-1)},
+		{Binding: m.keyMap.MoveRight, Run: m.move(1)},
+		{Binding: m.keyMap.EditTask, Run: m.editTask},
+	}
+}
+
+// move returns an action that moves the selected tasks n columns across, setting their status to that of the column.
+func (m *Model) move(n int) func() (tea.Model, tea.Cmd) {
+	return func() (tea.Model, tea.Cmd) {
+		to := m.focus + n
+		if to < 0 || to >= len(statuses) {
+			return nil, nil
+		}
+		status := statuses[to]
+		targets, now := m.columns[m.focus].Targets(), m.ctx.Now()
+		m.columns[m.focus].ClearMarks()
+		return nil, m.footer.Write("move", len(targets), func() []notedown.TaskError {
+			return notedown.WriteTasks(m.nd, targets, func(t tasks.Task) error {
+				return m.nd.UpdateTask(tasks.NewTaskFromTask(t, tasks.WithStatus(status, now)))
+			})
+		})
+	}
+}
+
+func (m *Model) editTask() (tea.Model, tea.Cmd) {
+	if selected := m.columns[m.focus].Selected(); selected != nil {
+		return m.ctx.Navigate(taskeditor.New(
+			m.ctx,
+			m.nd,
+			taskeditor.WithEdit(*selected, m.ctx.Now()),
+		))
+	}
+	return nil, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+type KeyMap struct {
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	PrevColumn key.Binding
+	NextColumn key.Binding
+
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+	EditTask  key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	CursorUp: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑/k", "move cursor up"),
+	),
+	CursorDown: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓/j", "move cursor down"),
+	),
+	PrevColumn: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("←/h", "previous column"),
+	),
+	NextColumn: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("→/l", "next column"),
+	),
+	MoveLeft: key.NewBinding(
+		key.WithKeys("H", "shift+h"),
+		key.WithHelp("H", "move the selected tasks to the previous column"),
+	),
+	MoveRight: key.NewBinding(
+		key.WithKeys("L", "shift+l"),
+		key.WithHelp("L", "move the selected tasks to the next column"),
+	),
+	EditTask: key.NewBinding(
+		key.WithKeys("e", "enter"),
+		key.WithHelp("e/enter", "edit the selected task"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"CursorUp":   &k.CursorUp,
+		"CursorDown": &k.CursorDown,
+		"PrevColumn": &k.PrevColumn,
+		"NextColumn": &k.NextColumn,
+		"MoveLeft":   &k.MoveLeft,
+		"MoveRight":  &k.MoveRight,
+		"EditTask":   &k.EditTask,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevColumn, k.NextColumn, k.MoveLeft, k.MoveRight, k.EditTask}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.CursorUp, k.CursorDown, k.PrevColumn, k.NextColumn, groupedlist.DefaultKeyMap.Filter},
+		{k.MoveLeft, k.MoveRight, k.EditTask},
+		{groupedlist.DefaultKeyMap.Mark, groupedlist.DefaultKeyMap.MarkRange, groupedlist.DefaultKeyMap.MarkGroup},
+	}
+}
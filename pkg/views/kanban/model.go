@@ -0,0 +1,165 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/listeners"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/styling/tasklists"
+)
+
+const (
+	view = "board"
+)
+
+// statuses are the columns of the board from left to right.
+var statuses = []tasks.Status{tasks.Todo, tasks.Doing, tasks.Blocked, tasks.Done}
+
+var statusName = map[tasks.Status]string{
+	tasks.Todo:    "Todo",
+	tasks.Doing:   "Doing",
+	tasks.Blocked: "Blocked",
+	tasks.Done:    "Done",
+}
+
+// Scope decides which tasks are shown on the board.
+type Scope func(*Model)
+
+// WithProject shows the tasks of a single project.
+func WithProject(project projects.Project) Scope {
+	return func(m *Model) {
+		m.title = project.Name()
+		m.fetch = func() []tasks.Task {
+			return m.nd.ListTasks(tasks.FetchTasksForDocument(project.Path()), tasks.WithSorters(tasks.SortByPriority()))
+		}
+	}
+}
+
+// WithDateRange shows the tasks that are on the agenda on or before the last day of the range, along with the tasks
+// completed within it.
+func WithDateRange(from time.Time, to time.Time) Scope {
+	return func(m *Model) {
+		m.title = fmt.Sprintf("%s – %s", from.Format("January 2"), to.Format("January 2, 2006"))
+		m.fetch = func() []tasks.Task { return dateRange(m.nd, from, to) }
+	}
+}
+
+func New(ctx *context.ProgramContext, nd notedown.Client, scope Scope) *Model {
+	m := &Model{
+		ctx:    ctx,
+		nd:     nd,
+		keyMap: DefaultKeyMap,
+		footer: statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
+	}
+	scope(m)
+	for range statuses {
+		m.columns = append(m.columns, groupedlist.New(
+			groupedlist.WithRenderers(renderers(ctx.Theme)),
+			groupedlist.WithMatcher(tasklists.Matcher),
+			groupedlist.WithIdentity(tasklists.Identity),
+		))
+	}
+	m.columns[0].Focus()
+	m.updateTasks()
+	return m
+}
+
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	keyMap KeyMap
+
+	title string
+	fetch func() []tasks.Task
+
+	columns []*groupedlist.Model[tasks.Task]
+	focus   int // index of the focused column
+
+	footer *statusbar.Model
+}
+
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	_, cmd := m.ctx.Init()
+	return m, cmd
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// Filtering is handled by the lists themselves, if they consume the key press there is nothing left to do
+		if handled, command := m.columns[m.focus].Update(msg); handled {
+			return m, command
+		}
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		}
+	}
+
+	// If the task client has emitted an event, refresh the tasks
+	if _, ok := msg.(listeners.TaskEvent); ok {
+		m.updateTasks()
+	}
+
+	// If we're being navigated back to, refresh the tasks
+	if _, ok := msg.(context.NavigationEvent); ok {
+		m.updateTasks()
+	}
+
+	// Handle program level key presses and events
+	model, command := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, tea.Batch(command, cmd)
+	}
+	cmd = tea.Batch(cmd, command)
+	return m, cmd
+}
+
+func (m *Model) updateTasks() {
+	all := m.fetch()
+	for i, status := range statuses {
+		items := tasks.WithFilter(tasks.FilterByStatus(status))(all)
+		m.columns[i].SetGroups([]groupedlist.Group[tasks.Task]{{Name: statusName[status], Items: items}})
+	}
+}
+
+func (m *Model) focusColumn(i int) {
+	if i < 0 || i >= len(m.columns) {
+		return
+	}
+	m.columns[m.focus].Blur()
+	m.focus = i
+	m.columns[m.focus].Focus()
+}
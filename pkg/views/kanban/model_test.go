@@ -0,0 +1,93 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const board = `# Board
+- [ ] Write spec due:2024-06-05
+- [x] Plan spec completed:2024-06-04
+`
+
+// newTestModel returns a board for the week of 2024-06-03 along with the root of its workspace.
+func newTestModel(t *testing.T) (*Model, string) {
+	t.Helper()
+	nd, root := testenv.Workspace(t, notedown.NewClient, map[string]string{"board.md": board})
+	week := WithDateRange(time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC))
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC)
+	return testenv.View(now, func(ctx *context.ProgramContext) *Model { return New(ctx, nd, week) }), root
+}
+
+func TestEmptyColumnsKeepTheirHeader(t *testing.T) {
+	m, _ := newTestModel(t)
+	m.ctx.ScreenWidth, m.ctx.ScreenHeight = 120, 30
+
+	view := m.View()
+	for _, name := range []string{"TODO", "DOING", "BLOCKED", "DONE"} {
+		if !strings.Contains(view, name) {
+			t.Errorf("view is missing the %s header:\n%s", name, view)
+		}
+	}
+}
+
+func TestMoveOffTheBoard(t *testing.T) {
+	tests := []struct {
+		name   string
+		column int
+		n      int
+		want   string // content of the file after the move
+	}{
+		{name: "left off the first column", column: 0, n: -1, want: board},
+		{name: "right off the last column", column: 3, n: 1, want: board},
+		{
+			name:   "right from the first column",
+			column: 0,
+			n:      1,
+			want:   strings.Replace(board, "- [ ] Write spec", "- [/] Write spec", 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, root := newTestModel(t)
+			m.focusColumn(tt.column)
+			if m.columns[m.focus].Selected() == nil {
+				t.Fatalf("column %d is empty", tt.column)
+			}
+
+			_, cmd := m.move(tt.n)()
+			if tt.want == board && cmd != nil {
+				t.Fatal("move() returned a write, want none")
+			}
+			if cmd != nil {
+				if msg, ok := cmd().(statusbar.WrittenEvent); !ok || len(msg.Errs) > 0 {
+					t.Fatalf("move() = %#v", msg)
+				}
+			}
+
+			if got := testenv.Read(t, root, "board.md"); got != tt.want {
+				t.Errorf("file is\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/styling/colors"
+	"github.com/notedownorg/task/pkg/styling/icons"
+	"github.com/notedownorg/task/pkg/themes"
+)
+
+var (
+	s = lipgloss.NewStyle
+	w = lipgloss.Width
+	h = lipgloss.Height
+)
+
+func renderers(theme themes.Theme) groupedlist.Renderers[tasks.Task] {
+	paddingHorizontal := 1
+
+	card := func(colorsFor func(themes.Theme, tasks.Status) (lipgloss.Color, lipgloss.Color, error)) func(tasks.Task, groupedlist.ItemState) string {
+		return func(task tasks.Task, state groupedlist.ItemState) string {
+			bg, fg, err := colorsFor(theme, task.Status())
+			if err != nil {
+				slog.Warn("unexpected task status", "status", task.Status())
+			}
+			icon := icons.Task(task.Status())
+			if state.Marked {
+				icon = icons.Marked
+			}
+			name := runewidth.Truncate(task.Name(), state.Width-w(icon)-1-2*paddingHorizontal, "…")
+			return s().Width(state.Width).Padding(0, paddingHorizontal).Background(bg).Foreground(fg).Render(icon + " " + name)
+		}
+	}
+
+	return groupedlist.Renderers[tasks.Task]{
+		Item:     card(colors.Task),
+		Selected: card(colors.TaskSelected),
+	}
+}
+
+// columnHeader is rendered above each column by the board rather than the list so it is shown even when the column is empty.
+func columnHeader(theme themes.Theme, status tasks.Status, width int) string {
+	bg := theme.Text
+	switch status {
+	case tasks.Doing:
+		bg = theme.Green
+	case tasks.Blocked:
+		bg = theme.Red
+	case tasks.Done:
+		bg = theme.TextFaint
+	}
+	return s().Margin(0, 0, 1, 0).
+		Width(width).
+		Background(bg).
+		Foreground(theme.TextCursor).
+		Bold(true).
+		Padding(0, 1).
+		Render(strings.ToUpper(statusName[status]))
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+func dateRange(nd notedown.Client, from time.Time, to time.Time) []tasks.Task {
+	// Tasks are in UTC, so we need to use that.
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+
+	return nd.ListTasks(
+		tasks.FetchAllTasks(),
+		tasks.WithFilter(
+			tasks.Or(
+				tasks.And(
+					tasks.FilterByStatus(tasks.Todo, tasks.Doing, tasks.Blocked),
+					tasks.Or(
+						tasks.FilterByDueDate(nil, &end),
+						tasks.FilterByScheduledDate(nil, &end),
+					),
+				),
+				tasks.And(
+					tasks.FilterByStatus(tasks.Done),
+					tasks.FilterByCompletedDate(&start, &end),
+				),
+			),
+		),
+		tasks.WithSorters(tasks.SortByPriority()),
+	)
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanban
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalPadding := 1
+	gap := "  "
+
+	header := m.title
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth - horizontalPadding*2).
+		View()
+
+	width := (m.ctx.ScreenWidth - horizontalPadding*2 - w(gap)*(len(m.columns)-1)) / len(m.columns)
+	height := m.ctx.ScreenHeight - h(footer) - h(header) - verticalPadding*2 - 2 // -2 for the gaps
+
+	columns := make([]string, 0, len(m.columns)*2)
+	for i, column := range m.columns {
+		if i > 0 {
+			columns = append(columns, gap)
+		}
+		top := columnHeader(m.ctx.Theme, statuses[i], width)
+		list := column.Width(width).Height(height - h(top)).View()
+		columns = append(columns, s().Width(width).Height(height).Render(lipgloss.JoinVertical(lipgloss.Left, top, list)))
+	}
+
+	main := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	panel := lipgloss.JoinVertical(lipgloss.Top, header, "", main, "", footer)
+
+	return s().Padding(verticalPadding, horizontalPadding).Render(panel)
+}
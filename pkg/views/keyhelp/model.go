@@ -0,0 +1,135 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyhelp
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+// helpProvider is implemented by views that expose their key map to the help overlay.
+type helpProvider interface {
+	Help() help.KeyMap
+}
+
+// HandleOpen opens the help overlay for the current view, or closes it if it is already open.
+func HandleOpen(nd notedown.Client) context.GlobalKeyHandler {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.Help,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
+			current, _ := ctx.History.Peek()
+			if _, ok := current.(*Model); ok {
+				return ctx.Back(), nil
+			}
+			return ctx.Navigate(New(ctx, nd, current))
+		},
+	}
+}
+
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	// keys is the key map of the view the overlay was opened from, nil if the view doesn't provide one.
+	keys help.KeyMap
+
+	footer *statusbar.Model
+}
+
+func New(ctx *context.ProgramContext, nd notedown.Client, view tea.Model) *Model {
+	m := &Model{
+		ctx:    ctx,
+		nd:     nd,
+		footer: statusbar.New(ctx, statusbar.NewMode("help", statusbar.ActionNeutral), nd),
+	}
+	if hp, ok := view.(helpProvider); ok {
+		m.keys = hp.Help()
+	}
+	return m
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Handle program level key presses and events
+	model, cmd := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, cmd
+	}
+	return m, cmd
+}
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalMargin := 1
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth-horizontalPadding*2).
+		Margin(verticalMargin, 0).
+		View()
+
+	h := help.New()
+	h.Styles.FullKey = lipgloss.NewStyle().Foreground(m.ctx.Theme.Blue)
+	h.Styles.FullDesc = lipgloss.NewStyle().Foreground(m.ctx.Theme.Text)
+	h.Styles.FullSeparator = lipgloss.NewStyle()
+
+	heading := lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint).MarginBottom(1)
+
+	// Groups are stacked rather than rendered side by side to keep the dialog narrow
+	sections := make([]string, 0)
+	if m.keys != nil {
+		sections = append(sections, heading.Render("View"))
+		for _, group := range m.keys.FullHelp() {
+			sections = append(sections, h.FullHelpView([][]key.Binding{group}), "")
+		}
+	}
+	sections = append(sections, heading.Render("Global"), h.FullHelpView([][]key.Binding{m.ctx.GlobalBindings()}))
+
+	top := lipgloss.NewStyle().
+		Margin(1, 3).
+		Render(lipgloss.JoinVertical(lipgloss.Top, sections...))
+
+	border := lipgloss.RoundedBorder()
+	var b strings.Builder
+	str := "Help"
+	for i := len(str) + 2; i <= lipgloss.Width(top); i++ {
+		b.WriteString(lipgloss.RoundedBorder().Top)
+	}
+	b.WriteString(str)
+	border.Top = b.String()
+
+	form := lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(m.ctx.Theme.Blue).
+		Render(top)
+
+	width := m.ctx.ScreenWidth - horizontalPadding*2
+	height := m.ctx.ScreenHeight - lipgloss.Height(footer)
+
+	dialog := lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, form)
+
+	panel := lipgloss.JoinVertical(lipgloss.Top, dialog, footer)
+
+	return lipgloss.NewStyle().Padding(0, horizontalPadding).Render(panel)
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package palette
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/fuzzy"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const (
+	width      = 60
+	maxVisible = 12
+)
+
+// HandleOpen opens the command palette for the current view, or closes it if it is already open.
+func HandleOpen(nd notedown.Client) context.GlobalKeyHandler {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.Palette,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
+			current, _ := ctx.History.Peek()
+			if _, ok := current.(*Model); ok {
+				return ctx.Back(), nil
+			}
+			return ctx.Navigate(New(ctx, nd, current))
+		},
+		Unlisted: true,
+	}
+}
+
+// command is a single entry in the palette, either an action of the view the palette was opened from or a
+// global key handler.
+type command struct {
+	name string
+	keys string
+	run  func() (tea.Model, tea.Cmd)
+}
+
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	keyMap KeyMap
+
+	input    textinput.Model
+	commands []command
+	matches  []int // indexes into commands, best match first
+	cursor   int
+
+	footer *statusbar.Model
+}
+
+func New(ctx *context.ProgramContext, nd notedown.Client, view tea.Model) *Model {
+	input := textinput.New()
+	input.Prompt = ": "
+	input.Placeholder = "Type to search commands"
+	input.Focus()
+
+	m := &Model{
+		ctx:    ctx,
+		nd:     nd,
+		keyMap: DefaultKeyMap,
+		input:  input,
+		footer: statusbar.New(ctx, statusbar.NewMode("command", statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
+	}
+
+	if ap, ok := view.(context.ActionProvider); ok {
+		for _, action := range ap.Actions() {
+			m.commands = append(m.commands, command{name: action.Name(), keys: action.Binding.Help().Key, run: action.Run})
+		}
+	}
+	for _, handler := range ctx.KeyHandlers {
+		if handler.Unlisted {
+			continue
+		}
+		handle := handler.Handle
+		m.commands = append(m.commands, command{
+			name: handler.Binding.Help().Desc,
+			keys: handler.Binding.Help().Key,
+			run:  func() (tea.Model, tea.Cmd) { return handle(ctx) },
+		})
+	}
+
+	m.filter()
+	return m
+}
+
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+// CapturingInput reports whether key presses are being typed into the search, which is always the case.
+func (m *Model) CapturingInput() bool {
+	return true
+}
+
+// Actions are dispatched from key presses, they are not listed in the palette itself.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		{Binding: m.keyMap.CursorUp, Run: func() (tea.Model, tea.Cmd) { m.moveUp(); return nil, nil }},
+		{Binding: m.keyMap.CursorDown, Run: func() (tea.Model, tea.Cmd) { m.moveDown(); return nil, nil }},
+		{Binding: m.keyMap.Run, Run: m.run},
+	}
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		} else {
+			previous := m.input.Value()
+			m.input, cmd = m.input.Update(msg)
+			if m.input.Value() != previous {
+				m.filter()
+			}
+		}
+	}
+
+	// Handle program level key presses and events
+	model, command := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, tea.Batch(command, cmd)
+	}
+	cmd = tea.Batch(cmd, command)
+	return m, cmd
+}
+
+// run closes the palette and runs the selected command against the view the palette was opened from.
+func (m *Model) run() (tea.Model, tea.Cmd) {
+	if len(m.matches) == 0 {
+		return nil, nil
+	}
+	selected := m.commands[m.matches[m.cursor]]
+	view := m.ctx.Back()
+	model, cmd := selected.run()
+	if model == nil {
+		return view, cmd
+	}
+	return model, cmd
+}
+
+func (m *Model) filter() {
+	names := make([]string, len(m.commands))
+	for i, c := range m.commands {
+		names[i] = c.name
+	}
+	m.matches = fuzzy.Filter(m.input.Value(), names)
+	m.cursor = 0
+}
+
+func (m *Model) moveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+func (m *Model) moveDown() {
+	if m.cursor < len(m.matches)-1 {
+		m.cursor++
+	}
+}
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalMargin := 1
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth-horizontalPadding*2).
+		Margin(verticalMargin, 0).
+		View()
+
+	m.input.Width = width - lipgloss.Width(m.input.Prompt) - 1
+
+	// Keep the cursor within the visible window of matches
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.matches))
+
+	rows := make([]string, 0, maxVisible)
+	for i := start; i < end; i++ {
+		c := m.commands[m.matches[i]]
+		style := lipgloss.NewStyle().Foreground(m.ctx.Theme.Text)
+		if i == m.cursor {
+			style = style.Foreground(m.ctx.Theme.TextCursor).Background(m.ctx.Theme.Blue)
+		}
+		keys := lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint).Inherit(style).Render(c.keys)
+		name := style.Width(width - lipgloss.Width(keys)).Render(" " + c.name)
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Left, name, keys))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint).Render(" no matching commands"))
+	}
+
+	top := lipgloss.NewStyle().
+		Margin(1, 3).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Top, m.input.View(), "", lipgloss.JoinVertical(lipgloss.Top, rows...)))
+
+	border := lipgloss.RoundedBorder()
+	var b strings.Builder
+	str := "Commands"
+	for i := len(str) + 2; i <= lipgloss.Width(top); i++ {
+		b.WriteString(lipgloss.RoundedBorder().Top)
+	}
+	b.WriteString(str)
+	border.Top = b.String()
+
+	form := lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(m.ctx.Theme.Blue).
+		Render(top)
+
+	width := m.ctx.ScreenWidth - horizontalPadding*2
+	height := m.ctx.ScreenHeight - lipgloss.Height(footer)
+
+	dialog := lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, form)
+
+	panel := lipgloss.JoinVertical(lipgloss.Top, dialog, footer)
+
+	return lipgloss.NewStyle().Padding(0, horizontalPadding).Render(panel)
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package palette
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+func TestGlobalCommands(t *testing.T) {
+	handle := func(*context.ProgramContext) (tea.Model, tea.Cmd) { return nil, nil }
+	m := testenv.View(time.Now(), func(ctx *context.ProgramContext) *Model {
+		ctx.SetGlobalKeyHandlers(
+			HandleOpen(nil),
+			// Same description as the palette handler, only the palette handler itself is left out
+			context.GlobalKeyHandler{Binding: key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", context.DefaultGlobalKeyMap.Palette.Help().Desc)), Handle: handle},
+			context.GlobalKeyHandler{Binding: key.NewBinding(key.WithHelp("", "add a new project")), Handle: handle},
+		)
+		return New(ctx, nil, nil)
+	})
+
+	got := make([]string, 0, len(m.commands))
+	for _, c := range m.commands {
+		got = append(got, c.keys+" "+c.name)
+	}
+	want := []string{"ctrl+o open the command palette", " add a new project"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
@@ -27,7 +27,10 @@ func (m *Model) submit() (tea.Model, tea.Cmd) {
 	path := m.location.file
 	name := strings.Replace(filepath.Base(path), filepath.Ext(path), "", 1)
 	if err := m.nd.CreateProject(path, name, projects.Backlog); err != nil {
-		slog.Error("failed to create project", "error", err)
+		// Stay open with the text as typed so it can be corrected or retried
+		slog.Error("failed to create project", "error", err, "text", m.text.Value())
+		m.failed = err
+		m.footer.SetError("add project", err, m.keyMap.Retry)
 		return m, nil
 	}
 
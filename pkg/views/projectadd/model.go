@@ -17,7 +17,7 @@ package projectadd
 import (
 	"strings"
 
-	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/help"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/notedownorg/task/pkg/components/statusbar"
@@ -32,12 +32,25 @@ const (
 	editing
 )
 
+// HandleNew opens the add project dialog, it is unbound by default so is mainly reached through the command palette.
+func HandleNew(nd notedown.Client) context.GlobalKeyHandler {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.AddProject,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
+			return ctx.Navigate(New(ctx, nd))
+		},
+	}
+}
+
 type Model struct {
 	ctx *context.ProgramContext
 	nd  notedown.Client
 
 	keyMap KeyMap
 
+	// failed is the error of the last submit, while set the submit can be retried
+	failed error
+
 	text     *Text
 	location *Location
 
@@ -53,24 +66,48 @@ func New(ctx *context.ProgramContext, nd notedown.Client) *Model {
 
 		text:     NewText(ctx).Focus(),
 		location: NewLocation(ctx),
-		footer:   statusbar.New(ctx, statusbar.NewMode("add project", statusbar.ActionCreate), nd),
+		footer:   statusbar.New(ctx, statusbar.NewMode("add project", statusbar.ActionCreate), nd).SetHelp(DefaultKeyMap),
 	}
 	return m
 }
 
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
 func (m *Model) Init() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// CapturingInput reports whether key presses are being typed into the project text, which is always the case.
+func (m *Model) CapturingInput() bool {
+	return true
+}
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	actions := []context.Action{
+		{Binding: m.keyMap.Submit, Run: m.submit},
+	}
+	if m.failed != nil {
+		actions = append(actions, context.Action{Binding: m.keyMap.Retry, Run: m.submit})
+	}
+	return actions
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	// Handle view level key presses
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, m.keyMap.Submit):
-			return m.submit()
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
 		}
 	}
 
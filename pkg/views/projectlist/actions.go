@@ -0,0 +1,75 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectlist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/views/confirm"
+	"github.com/notedownorg/task/pkg/views/projectadd"
+	"github.com/notedownorg/task/pkg/views/projectmanager"
+)
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		{Binding: m.keyMap.TogglePanels, Run: func() (tea.Model, tea.Cmd) { m.togglePanels(); return nil, nil }},
+		{Binding: m.keyMap.AddProject, Run: m.addProject},
+		{Binding: m.keyMap.EditProject, Run: m.editProject},
+		{Binding: m.keyMap.DeleteProject, Run: m.deleteProject},
+		{Binding: m.keyMap.CursorUp, Run: func() (tea.Model, tea.Cmd) { m.moveUp(1); return nil, nil }},
+		{Binding: m.keyMap.CursorDown, Run: func() (tea.Model, tea.Cmd) { m.moveDown(1); return nil, nil }},
+	}
+}
+
+func (m *Model) addProject() (tea.Model, tea.Cmd) {
+	return m.ctx.Navigate(projectadd.New(m.ctx, m.nd))
+}
+
+func (m *Model) editProject() (tea.Model, tea.Cmd) {
+	if selected := m.selectedProject(); selected != nil {
+		return m.ctx.Navigate(projectmanager.New(m.ctx, m.nd, *selected))
+	}
+	return nil, nil
+}
+
+func (m *Model) deleteProject() (tea.Model, tea.Cmd) {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	names := make([]string, len(targets))
+	for i, project := range targets {
+		names[i] = project.Name()
+	}
+	return confirm.Guard(m.ctx, m.nd, confirm.DeleteProject, confirm.Describe("delete", "project", names...), func() (tea.Model, tea.Cmd) {
+		failures := make([]string, 0)
+		for _, err := range notedown.DeleteProjects(m.nd, targets) {
+			failures = append(failures, err.Error())
+		}
+		if len(failures) > 0 {
+			message := fmt.Sprintf("failed to delete %d of %d projects: %s", len(failures), len(targets), strings.Join(failures, "; "))
+			m.footer.SetMessage(message, time.Now().Add(10*time.Second), m.ctx.Theme.Red)
+		}
+		m.projectlist.ClearMarks()
+		m.closed.ClearMarks()
+		return nil, nil
+	})
+}
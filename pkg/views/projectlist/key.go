@@ -14,7 +14,11 @@
 
 package projectlist
 
-import "github.com/charmbracelet/bubbles/v2/key"
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/context"
+)
 
 type KeyMap struct {
 	TogglePanels  key.Binding
@@ -41,7 +45,7 @@ var DefaultKeyMap = KeyMap{
 	),
 	DeleteProject: key.NewBinding(
 		key.WithKeys("d"),
-		key.WithHelp("d", "delete a task"),
+		key.WithHelp("d", "delete the selected projects"),
 	),
 	CursorUp: key.NewBinding(
 		key.WithKeys("k", "up"),
@@ -52,3 +56,29 @@ var DefaultKeyMap = KeyMap{
 		key.WithHelp("↓/j", "move cursor down"),
 	),
 }
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"TogglePanels":  &k.TogglePanels,
+		"AddProject":    &k.AddProject,
+		"EditProject":   &k.EditProject,
+		"DeleteProject": &k.DeleteProject,
+		"CursorUp":      &k.CursorUp,
+		"CursorDown":    &k.CursorDown,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.AddProject, k.EditProject, k.TogglePanels}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.CursorUp, k.CursorDown, k.TogglePanels, groupedlist.DefaultKeyMap.Filter},
+		{k.AddProject, k.EditProject, k.DeleteProject},
+		{groupedlist.DefaultKeyMap.Mark, groupedlist.DefaultKeyMap.MarkRange, groupedlist.DefaultKeyMap.MarkGroup},
+	}
+}
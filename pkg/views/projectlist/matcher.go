@@ -0,0 +1,43 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectlist
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/task/pkg/components/groupedlist"
+	"github.com/notedownorg/task/pkg/fuzzy"
+)
+
+const pathPrefix = "path:"
+
+// matcher filters projects by fuzzy matching their name. Tokens prefixed with path: match part of the path of the
+// project instead, e.g. "garden path:home".
+func matcher(project projects.Project, query string) bool {
+	text, values := groupedlist.ParseQuery(query, pathPrefix)
+	if path, ok := values[pathPrefix]; ok && !strings.Contains(strings.ToLower(project.Path()), strings.ToLower(path)) {
+		return false
+	}
+	_, ok := fuzzy.Match(text, project.Name())
+	return ok
+}
+
+// highlight renders the name of a project with the runes matched by the query emphasised.
+func highlight(name string, query string, base lipgloss.Style) string {
+	text, _ := groupedlist.ParseQuery(query, pathPrefix)
+	return groupedlist.Highlight(name, text, base, base.Bold(true).Underline(true))
+}
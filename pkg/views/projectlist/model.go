@@ -15,10 +15,7 @@
 package projectlist
 
 import (
-	"fmt"
-	"time"
-
-	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/help"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/notedownorg/notedown/pkg/providers/projects"
 	"github.com/notedownorg/task/pkg/components/groupedlist"
@@ -26,8 +23,6 @@ import (
 	"github.com/notedownorg/task/pkg/context"
 	"github.com/notedownorg/task/pkg/listeners"
 	"github.com/notedownorg/task/pkg/notedown"
-	"github.com/notedownorg/task/pkg/views/projectadd"
-	"github.com/notedownorg/task/pkg/views/projectmanager"
 )
 
 const (
@@ -35,12 +30,11 @@ const (
 )
 
 func HandleNew(nd notedown.Client) context.GlobalKeyHandler {
-	return func(ctx *context.ProgramContext, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-		key := msg.Key()
-		if key.Mod == tea.ModCtrl && key.Code == 'p' {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.Projects,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
 			return ctx.Navigate(New(ctx, nd))
-		}
-		return nil, nil
+		},
 	}
 }
 
@@ -51,9 +45,9 @@ func New(ctx *context.ProgramContext, nd notedown.Client) *Model {
 
 		keyMap: DefaultKeyMap,
 
-		projectlist: groupedlist.New(groupedlist.WithRenderers(mainRendererFuncs(ctx.Theme))).Focus(),
-		closed:      groupedlist.New(groupedlist.WithRenderers(closedRendererFuncs(ctx.Theme))),
-		footer:      statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd),
+		projectlist: groupedlist.New(groupedlist.WithRenderers(mainRendererFuncs(ctx.Theme)), groupedlist.WithMatcher(matcher), groupedlist.WithIdentity(projects.Project.Path)).Focus(),
+		closed:      groupedlist.New(groupedlist.WithRenderers(closedRendererFuncs(ctx.Theme)), groupedlist.WithMatcher(matcher), groupedlist.WithIdentity(projects.Project.Path)),
+		footer:      statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
 	}
 	m.updateProjects()
 	return m
@@ -70,6 +64,11 @@ type Model struct {
 	footer      *statusbar.Model
 }
 
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
 func (m *Model) Init() (tea.Model, tea.Cmd) {
 	_, cmd := m.ctx.Init()
 	return m, cmd
@@ -80,25 +79,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, m.keyMap.TogglePanels):
-			m.togglePanels()
-		case key.Matches(msg, m.keyMap.AddProject):
-			return m.ctx.Navigate(projectadd.New(m.ctx, m.nd))
-		case key.Matches(msg, m.keyMap.EditProject):
-			if selected := m.selectedProject(); selected != nil {
-				return m.ctx.Navigate(projectmanager.New(m.ctx, m.nd, *selected))
-			}
-		case key.Matches(msg, m.keyMap.DeleteProject):
-			if selected := m.selectedProject(); selected != nil {
-				if err := m.nd.DeleteProject(*selected); err != nil {
-					m.footer.SetMessage(fmt.Sprintf("error deleting project: %v", err), time.Now().Add(10*time.Second), m.ctx.Theme.Red)
-				}
+		// Filtering is handled by the lists themselves, if they consume the key press there is nothing left to do
+		if handled, command := m.projectlist.Update(msg); handled {
+			return m, command
+		}
+		if handled, command := m.closed.Update(msg); handled {
+			return m, command
+		}
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
 			}
-		case key.Matches(msg, m.keyMap.CursorUp):
-			m.moveUp(1)
-		case key.Matches(msg, m.keyMap.CursorDown):
-			m.moveDown(1)
+			cmd = command
 		}
 	}
 
@@ -116,6 +109,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// targets are the projects that bulk operations apply to in the focused list.
+func (m *Model) targets() []projects.Project {
+	if m.projectlist.Focused() {
+		return m.projectlist.Targets()
+	}
+	return m.closed.Targets()
+}
+
 func (m *Model) selectedProject() *projects.Project {
 	if m.projectlist.Focused() {
 		return m.projectlist.Selected()
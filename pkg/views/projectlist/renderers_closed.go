@@ -22,7 +22,6 @@ import (
 	"github.com/mattn/go-runewidth"
 	"github.com/notedownorg/notedown/pkg/providers/projects"
 	"github.com/notedownorg/task/pkg/components/groupedlist"
-	"github.com/notedownorg/task/pkg/styling/icons"
 	"github.com/notedownorg/task/pkg/themes"
 )
 
@@ -49,31 +48,33 @@ func closedRendererFuncs(theme themes.Theme) groupedlist.Renderers[projects.Proj
 				"",
 			)
 		},
-		Item: func(project projects.Project, width int) string {
+		Item: func(project projects.Project, state groupedlist.ItemState) string {
+			width := state.Width
 			fields := []string{
-				icons.Project(project.Status()),
+				icon(project, state),
 				s().Render(runewidth.Truncate(project.Name(), width-paddingHorizontal*2-3, "…")), // need to account for icon and padding
 			}
 
 			switch project.Status() {
 			case projects.Archived, projects.Abandoned:
 				base := s().Background(theme.Panel).Foreground(theme.TextFaint)
-				return s().Width(width).Background(theme.Panel).Padding(0, paddingHorizontal).Render(base.Render(fields[0]+"  ") + base.Strikethrough(true).Render(fields[1]))
+				return s().Width(width).Background(theme.Panel).Padding(0, paddingHorizontal).Render(base.Render(fields[0]+"  ") + highlight(fields[1], state.Query, base.Strikethrough(true)))
 			}
 
 			slog.Warn("unexpected project status", "status", project.Status())
 			return ""
 		},
-		Selected: func(project projects.Project, width int) string {
+		Selected: func(project projects.Project, state groupedlist.ItemState) string {
+			width := state.Width
 			fields := []string{
-				icons.Project(project.Status()),
+				icon(project, state),
 				lipgloss.NewStyle().Render(runewidth.Truncate(project.Name(), width-paddingHorizontal*2-3, "…")), // need to account for icon and padding
 			}
 
 			switch project.Status() {
 			case projects.Archived, projects.Abandoned:
 				base := s().Background(theme.TextFaint).Foreground(theme.TextCursor)
-				return s().Width(width).Padding(0, paddingHorizontal).Background(theme.TextFaint).Render(base.Render(fields[0]+"  ") + base.Strikethrough(true).Render(fields[1]))
+				return s().Width(width).Padding(0, paddingHorizontal).Background(theme.TextFaint).Render(base.Render(fields[0]+"  ") + highlight(fields[1], state.Query, base.Strikethrough(true)))
 			}
 
 			slog.Warn("unexpected project status", "status", project.Status())
@@ -66,7 +66,8 @@ func mainRendererFuncs(theme themes.Theme) groupedlist.Renderers[projects.Projec
 			)
 		},
 
-		Item: func(project projects.Project, width int) string {
+		Item: func(project projects.Project, state groupedlist.ItemState) string {
+			width := state.Width
 			bg, fg, err := colors.Project(theme, project.Status())
 			if err != nil {
 				slog.Warn("unexpected project status", "status", project.Status())
@@ -76,7 +77,7 @@ func mainRendererFuncs(theme themes.Theme) groupedlist.Renderers[projects.Projec
 			right := buildRight(false)(theme, project, bg)
 
 			remainingSpace := width - w(right) - 2*paddingHorizontal
-			left := buildLeft(false)(theme, project, remainingSpace, bg)
+			left := buildLeft(false)(theme, project, remainingSpace, bg, fg, state)
 
 			middlePadding := width - w(left) - w(right) - 2*paddingHorizontal
 			middle := s().Background(bg).PaddingRight(middlePadding).Render("") // fill out the rest of the space
@@ -84,7 +85,8 @@ func mainRendererFuncs(theme themes.Theme) groupedlist.Renderers[projects.Projec
 			return s().Width(width).Padding(0, paddingHorizontal).Background(bg).Foreground(fg).Render(left + middle + right)
 		},
 
-		Selected: func(project projects.Project, width int) string {
+		Selected: func(project projects.Project, state groupedlist.ItemState) string {
+			width := state.Width
 			bg, fg, err := colors.ProjectSelected(theme, project.Status())
 			if err != nil {
 				slog.Warn("unexpected project status", "status", project.Status())
@@ -94,7 +96,7 @@ func mainRendererFuncs(theme themes.Theme) groupedlist.Renderers[projects.Projec
 			right := buildRight(true)(theme, project, bg)
 
 			remainingSpace := width - w(right) - 2*paddingHorizontal
-			left := buildLeft(true)(theme, project, remainingSpace, bg)
+			left := buildLeft(true)(theme, project, remainingSpace, bg, fg, state)
 
 			middlePadding := width - w(left) - w(right) - 2*paddingHorizontal
 			middle := s().Background(bg).PaddingRight(middlePadding).Render("") // fill out the rest of the space
@@ -104,6 +106,13 @@ func mainRendererFuncs(theme themes.Theme) groupedlist.Renderers[projects.Projec
 	}
 }
 
+func icon(project projects.Project, state groupedlist.ItemState) string {
+	if state.Marked {
+		return icons.Marked
+	}
+	return icons.Project(project.Status())
+}
+
 // See https://github.com/charmbracelet/lipgloss/issues/144 for why we need to pass bg
 func buildRight(selected bool) func(theme themes.Theme, project projects.Project, bg lipgloss.Color) string {
 	return func(theme themes.Theme, project projects.Project, bg lipgloss.Color) string {
@@ -112,15 +121,18 @@ func buildRight(selected bool) func(theme themes.Theme, project projects.Project
 }
 
 // See https://github.com/charmbracelet/lipgloss/issues/144 for why we need to pass bg
-func buildLeft(selected bool) func(theme themes.Theme, project projects.Project, remainingSpace int, bg lipgloss.Color) string {
-	return func(theme themes.Theme, project projects.Project, remainingSpace int, bg lipgloss.Color) string {
+func buildLeft(selected bool) func(theme themes.Theme, project projects.Project, remainingSpace int, bg lipgloss.Color, fg lipgloss.Color, state groupedlist.ItemState) string {
+	return func(theme themes.Theme, project projects.Project, remainingSpace int, bg lipgloss.Color, fg lipgloss.Color, state groupedlist.ItemState) string {
 		res := make([]string, 0)
 
-		i := icons.Project(project.Status())
+		i := icon(project, state)
 		res = append(res, i, "  ")
 
 		textWidth := remainingSpace - w(i)
 		text := runewidth.Truncate(project.Name(), textWidth, "…")
+		if state.Query != "" {
+			text = highlight(text, state.Query, s().Background(bg).Foreground(fg))
+		}
 		res = append(res, text)
 
 		return strings.Join(res, "")
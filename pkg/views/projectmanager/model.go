@@ -15,10 +15,7 @@
 package projectmanager
 
 import (
-	"fmt"
-	"time"
-
-	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/help"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/notedownorg/notedown/pkg/providers/projects"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
@@ -28,8 +25,6 @@ import (
 	"github.com/notedownorg/task/pkg/listeners"
 	"github.com/notedownorg/task/pkg/notedown"
 	"github.com/notedownorg/task/pkg/styling/tasklists"
-	"github.com/notedownorg/task/pkg/views/taskeditor"
-	"github.com/notedownorg/task/pkg/views/taskreschedule"
 )
 
 type Model struct {
@@ -54,15 +49,20 @@ func New(ctx *context.ProgramContext, nd notedown.Client, project projects.Proje
 		project:   project,
 		status:    NewStatus(ctx, project.Status()),
 		text:      NewText(ctx, project.Name()),
-		completed: groupedlist.New(groupedlist.WithRenderers(tasklists.CompletedRenderers(ctx.Theme))),
-		footer:    statusbar.New(ctx, statusbar.NewMode("manage project", statusbar.ActionNeutral), nd),
+		completed: groupedlist.New(groupedlist.WithRenderers(tasklists.CompletedRenderers(ctx.Theme)), groupedlist.WithMatcher(tasklists.Matcher), groupedlist.WithIdentity(tasklists.Identity)),
+		footer:    statusbar.New(ctx, statusbar.NewMode("manage project", statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
 	}
-	m.tasklist = groupedlist.New(groupedlist.WithRenderers(tasklists.MainRenderers(ctx.Theme, ctx.Now))).Focus()
+	m.tasklist = groupedlist.New(groupedlist.WithRenderers(tasklists.MainRenderers(ctx.Theme, ctx.Now)), groupedlist.WithMatcher(tasklists.Matcher), groupedlist.WithIdentity(tasklists.Identity)).Focus()
 	m.updateTasks()
 
 	return m
 }
 
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
 func (m *Model) Init() (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -76,56 +76,30 @@ func (m *Model) Init() (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// CapturingInput reports whether key presses are being typed into the project name.
+func (m *Model) CapturingInput() bool {
+	return m.text.focused
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	// Internal to the project manager
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, m.keyMap.ToggleFocus):
-			cmd = tea.Batch(m.toggleFocus())
-		case key.Matches(msg, m.keyMap.CursorUp):
-			m.moveUp(1)
-		case key.Matches(msg, m.keyMap.CursorDown):
-			m.moveDown(1)
-
-		// Navigation
-		case key.Matches(msg, m.keyMap.AddTask):
-			return m.ctx.Navigate(taskeditor.New(
-				m.ctx,
-				m.nd,
-				taskeditor.WithAddToProject(tasks.Todo, "", m.project, m.ctx.Now()),
-			))
-		case key.Matches(msg, m.keyMap.EditTask):
-			if selected := m.selectedTask(); selected != nil {
-				return m.ctx.Navigate(taskeditor.New(
-					m.ctx,
-					m.nd,
-					taskeditor.WithEdit(*selected, m.ctx.Now()),
-				))
-			}
-
-		// Other Task operations
-		case key.Matches(msg, m.keyMap.RescheduleTask):
-			if selected := m.selectedTask(); selected != nil {
-				return m.ctx.Navigate(taskreschedule.New(m.ctx, m.nd, selected))
-			}
-
-		case key.Matches(msg, m.keyMap.CompleteTask):
-			if selected := m.selectedTask(); selected != nil {
-				t := tasks.NewTaskFromTask(*selected, tasks.WithStatus(tasks.Done, m.ctx.Now()))
-				if err := m.nd.UpdateTask(t); err != nil {
-					m.footer.SetMessage(fmt.Sprintf("error completing task: %v", err), time.Now().Add(10*time.Second), m.ctx.Theme.Red)
-				}
-			}
-
-		case key.Matches(msg, m.keyMap.DeleteTask):
-			if selected := m.selectedTask(); selected != nil {
-				if err := m.nd.DeleteTask(*selected); err != nil {
-					m.footer.SetMessage(fmt.Sprintf("error deleting task: %v", err), time.Now().Add(10*time.Second), m.ctx.Theme.Red)
-				}
+		// Filtering is handled by the lists themselves, if they consume the key press there is nothing left to do
+		if handled, command := m.tasklist.Update(msg); handled {
+			return m, command
+		}
+		if handled, command := m.completed.Update(msg); handled {
+			return m, command
+		}
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
 			}
+			cmd = command
 		}
 	}
 	// Handle component events
@@ -153,6 +127,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// targets are the tasks that bulk operations apply to in the focused list.
+func (m *Model) targets() []tasks.Task {
+	if m.completed.Focused() {
+		return m.completed.Targets()
+	}
+	return m.tasklist.Targets()
+}
+
+func (m *Model) clearMarks() {
+	m.tasklist.ClearMarks()
+	m.completed.ClearMarks()
+}
+
 func (m *Model) selectedTask() *tasks.Task {
 	if m.tasklist.Focused() {
 		return m.tasklist.Selected()
@@ -15,14 +15,31 @@
 package taskeditor
 
 import (
+	"fmt"
 	"log/slog"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
-	"github.com/notedownorg/notedown/pkg/fileserver/writer"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/notedown"
 )
 
 func (m *Model) submit() (tea.Model, tea.Cmd) {
+	// The fields are only updated while the text is valid so they may be stale
+	if !m.text.IsValid {
+		message := "fix the task before saving"
+		switch n := len(m.text.Diagnostics); {
+		case n == 1:
+			message = "fix the problem with the task before saving"
+		case n > 1:
+			message = fmt.Sprintf("fix the %d problems with the task before saving", n)
+		case m.fields.Every != nil && len(m.fields.Occurrences) == 0:
+			message = fmt.Sprintf("every:%s never repeats, fix it before saving", m.fields.Every)
+		}
+		m.footer.SetMessage(message, time.Now().Add(3*time.Second), m.ctx.Theme.Red)
+		return nil, nil
+	}
+
 	// Build the task
 	opts := make([]tasks.TaskOption, 0)
 	if m.fields.Due != nil {
@@ -43,11 +60,8 @@ func (m *Model) submit() (tea.Model, tea.Cmd) {
 
 	// Create/Update are intentionally run syncronously to prevent losing progress on error
 	if m.mode == adding {
-		if err := m.nd.CreateTask(m.location.file, writer.AT_END, m.fields.Name, m.status.Value(), opts...); err != nil {
-			slog.Error("failed to create task", "error", err)
-
-			// TODO: We should probably show an error message to the user
-			return m, nil
+		if err := m.nd.CreateTask(m.location.File(), m.location.Line(), m.fields.Name, m.status.Value(), opts...); err != nil {
+			return m.fail("add task", err)
 		}
 
 		// If we've successfully created the task, we can navigate back to the previous view
@@ -59,14 +73,51 @@ func (m *Model) submit() (tea.Model, tea.Cmd) {
 	opts = append(opts, tasks.WithStatus(m.status.Value(), m.date))
 
 	task := tasks.NewTaskFromTask(*m.original, opts...)
+	if m.location.Moved() {
+		slog.Debug("moving edited task", "identifier", task.Identifier().String(), "task", task.String(), "path", m.location.File(), "line", m.location.Line())
+		if err := m.nd.MoveTask(task, m.location.File(), m.location.Line()); err != nil {
+			return m.fail("move task", err)
+		}
+		return m.ctx.Back(), nil
+	}
+
 	slog.Debug("submitting edited task", "identifier", task.Identifier().String(), "task", task.String())
 	if err := m.nd.UpdateTask(task); err != nil {
-		slog.Error("failed to update task", "error", err)
-
-		// TODO: We should probably show an error message to the user
-		return m, nil
+		return m.fail("save task", err)
 	}
 
 	// If we've successfully created the task, we can navigate back to the previous view
 	return m.ctx.Back(), nil
 }
+
+// fail keeps the editor open with the text as typed and reports the error in the statusbar. The text is also logged
+// so it can be recovered from the log file if the editor is closed without retrying.
+func (m *Model) fail(verb string, err error) (tea.Model, tea.Cmd) {
+	slog.Error("failed to "+verb, "error", err, "text", m.text.Value())
+	m.failed = err
+	m.footer.SetError(verb, err, m.keyMap.Retry)
+	return m, nil
+}
+
+// retry submits again, when editing the task is re-read first in case the failure was caused by a stale version.
+func (m *Model) retry() (tea.Model, tea.Cmd) {
+	if m.mode == editing {
+		current, ok := m.reread()
+		if !ok {
+			return m.fail("save task", fmt.Errorf("%s:%d: %w", m.original.Path(), m.original.Line(), notedown.ErrTaskMoved))
+		}
+		m.original = &current
+	}
+	m.failed = nil
+	return m.submit()
+}
+
+// reread returns the latest version of the task being edited, if it is still on the same line.
+func (m *Model) reread() (tasks.Task, bool) {
+	for _, t := range m.nd.ListTasks(tasks.FetchTasksForDocument(m.original.Path())) {
+		if t.Line() == m.original.Line() && t.Name() == m.original.Name() {
+			return t, true
+		}
+	}
+	return tasks.Task{}, false
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskeditor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const list = "# A\n- [ ] Buy milk\n"
+
+// newTestEditor opens the editor on the task of a.md with the clock fixed at now, it returns the root of the workspace.
+func newTestEditor(t *testing.T, now time.Time) (*Model, string) {
+	t.Helper()
+	nd, root := testenv.Workspace(t, notedown.NewClient, map[string]string{"a.md": list})
+	found := nd.ListTasks(tasks.FetchTasksForDocument("a.md"))
+	if len(found) != 1 {
+		t.Fatalf("found %d tasks in a.md, want 1", len(found))
+	}
+	return testenv.View(now, func(ctx *context.ProgramContext) *Model { return New(ctx, nd, WithEdit(found[0], now)) }), root
+}
+
+func TestSubmit(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		invalid bool   // the text is marked invalid without a diagnostic, as when it can't be parsed
+		want    string // a.md after submitting
+		message string // shown in the statusbar when the task isn't saved
+	}{
+		{name: "valid", text: "Buy oat milk p:1", want: "# A\n- [ ] Buy oat milk priority:1\n"},
+		{name: "high priority", text: "Buy oat milk p:11", want: "# A\n- [ ] Buy oat milk priority:11\n"},
+		{name: "field problem", text: "Buy oat milk due:someday", want: list, message: "fix the problem with the task before saving"},
+		{name: "several problems", text: "Buy oat milk due:someday p:x", want: list, message: "fix the 2 problems with the task before saving"},
+		{name: "rule that never repeats", text: "Buy oat milk every:30 feb", want: list, message: "fix the problem with the task before saving"},
+		{name: "invalid without a diagnostic", text: "Buy oat milk", invalid: true, want: list, message: "fix the task before saving"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, root := newTestEditor(t, time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC))
+			m.text.SetValue(tt.text)
+			m.parseTask()
+			if tt.invalid {
+				m.text.IsValid = false
+			}
+
+			m.submit()
+			if got := testenv.Read(t, root, "a.md"); got != tt.want {
+				t.Errorf("a.md = %q, want %q", got, tt.want)
+			}
+			if footer := m.footer.Width(200).View(); tt.message != "" && !strings.Contains(footer, tt.message) {
+				t.Errorf("statusbar = %q, want %q", footer, tt.message)
+			}
+		})
+	}
+}
+
+func TestOccurrencesUseTheProgramClock(t *testing.T) {
+	m, _ := newTestEditor(t, time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC))
+	m.text.SetValue("Stretch every:day")
+	m.parseTask()
+
+	if !m.text.IsValid {
+		t.Fatalf("text is invalid: %v", m.text.Diagnostics)
+	}
+	want := make([]time.Time, 0, previewOccurrences)
+	for i := 1; i <= previewOccurrences; i++ {
+		want = append(want, time.Date(2024, 6, 5+i, 0, 0, 0, 0, time.UTC))
+	}
+	if !reflect.DeepEqual(m.fields.Occurrences, want) {
+		t.Errorf("occurrences = %v, want %v", m.fields.Occurrences, want)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskeditor
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/dates"
+)
+
+// Suggestion is a completion for the word being typed.
+type Suggestion struct {
+	Value  string
+	Detail string
+}
+
+// maxSuggestions is the number of suggestions shown in the popup.
+const maxSuggestions = 6
+
+// fieldKeys are suggested in the order they're most often used.
+var fieldKeys = []Suggestion{
+	{"due:", "due date"},
+	{"scheduled:", "scheduled date"},
+	{"priority:", "priority"},
+	{"every:", "recurrence rule"},
+	{"completed:", "completion date"},
+}
+
+var everyPhrases = []Suggestion{
+	{"day", "daily"},
+	{"weekday", "monday to friday"},
+	{"weekend", "saturdays"},
+	{"week", "weekly"},
+	{"2 weeks", "fortnightly"},
+	{"month", "monthly"},
+	{"year", "yearly"},
+	{"mon", "mondays"},
+	{"tue", "tuesdays"},
+	{"wed", "wednesdays"},
+	{"thu", "thursdays"},
+	{"fri", "fridays"},
+	{"sat", "saturdays"},
+	{"sun", "sundays"},
+	{"1 15", "the 1st and 15th of each month"},
+}
+
+// complete returns the suggestions for the word before the cursor and the rune offsets of the text they replace.
+func (m *Model) complete(text string, cursor int) (int, int, []Suggestion) {
+	runes := []rune(text)
+	if cursor > len(runes) {
+		cursor = len(runes)
+	}
+
+	// Project links can contain spaces so look for an unclosed [[ first
+	before := string(runes[:cursor])
+	if i := strings.LastIndex(before, "[["); i >= 0 && !strings.Contains(before[i:], "]]") {
+		start := len([]rune(before[:i]))
+		end := cursor
+		if j := strings.Index(string(runes[cursor:]), "]]"); j >= 0 && !strings.Contains(string(runes[cursor:])[:j], "[[") {
+			end = cursor + len([]rune(string(runes[cursor:])[:j])) + 2
+		}
+		return start, end, m.filter(m.projectLinks(), before[i+2:], "[[")
+	}
+
+	start, end := cursor, cursor
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+	word := string(runes[start:cursor])
+
+	if strings.HasPrefix(word, "#") {
+		return start, end, m.filter(m.tags(), word, "")
+	}
+
+	key, value, ok := strings.Cut(word, ":")
+	if !ok {
+		if len(word) < 2 || !isWord(word) {
+			return 0, 0, nil
+		}
+		return start, end, m.filter(fieldKeys, word, "")
+	}
+
+	// Only the value is replaced, every rules are made of several words so they're only completed while typing the first
+	start += len([]rune(key)) + 1
+	switch kind, known := fieldKinds[key]; {
+	case !known:
+		return 0, 0, nil
+	case kind == dateField || kind == completedField:
+		return start, end, m.filter(m.dateSuggestions(), value, "")
+	case kind == priorityField:
+		return start, end, m.filter(prioritySuggestions(), value, "")
+	}
+	return start, end, m.filter(everyPhrases, value, "")
+}
+
+// filter keeps the suggestions whose value, or any word of their detail, starts with the typed text. A suggestion
+// that is exactly what has been typed already is dropped so the popup closes once the word is complete, unless longer
+// suggestions still match.
+func (m *Model) filter(suggestions []Suggestion, typed string, prefix string) []Suggestion {
+	typed = strings.ToLower(typed)
+	var res []Suggestion
+	for _, s := range suggestions {
+		value := strings.ToLower(strings.TrimPrefix(s.Value, prefix))
+		if value == typed {
+			continue
+		}
+		matches := strings.HasPrefix(value, typed)
+		for _, word := range strings.Fields(strings.ToLower(s.Detail)) {
+			matches = matches || strings.HasPrefix(word, typed)
+		}
+		if matches {
+			res = append(res, s)
+		}
+		if len(res) == maxSuggestions {
+			break
+		}
+	}
+	return res
+}
+
+// dateSuggestions are ISO dates around the date the editor was opened for, along with how they relate to it.
+func (m *Model) dateSuggestions() []Suggestion {
+	cal := m.ctx.Calendar()
+	date := time.Date(m.date.Year(), m.date.Month(), m.date.Day(), 0, 0, 0, 0, time.UTC)
+	today, tomorrow := "same day", "next day"
+	if now := m.ctx.Now(); date.Equal(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		today, tomorrow = "today", "tomorrow"
+	}
+
+	candidates := []struct {
+		date  time.Time
+		label string
+	}{
+		{date, today},
+		{cal.AddDays(date, 1), tomorrow},
+	}
+	for i := 2; i <= 7; i++ {
+		day := date.AddDate(0, 0, i)
+		candidates = append(candidates, struct {
+			date  time.Time
+			label string
+		}{day, strings.ToLower(day.Weekday().String())})
+	}
+	for _, c := range []struct {
+		expr, label string
+	}{
+		{"+1w", "in a week"},
+		{"+2w", "in a fortnight"},
+		{"eow", "end of week"},
+		{"next-week", "next week"},
+		{"eom", "end of month"},
+		{"next-month", "next month"},
+	} {
+		d, _ := dates.Parse(c.expr, date, m.ctx.WeekStart(), cal)
+		candidates = append(candidates, struct {
+			date  time.Time
+			label string
+		}{d, c.label})
+	}
+
+	// The same date can be reached several ways, keep one suggestion per date with all of its labels
+	var res []Suggestion
+	for _, c := range candidates {
+		value := c.date.Format(dates.Layout)
+		i := slices.IndexFunc(res, func(s Suggestion) bool { return s.Value == value })
+		if i < 0 {
+			res = append(res, Suggestion{value, c.date.Format("Mon") + " " + c.label})
+			continue
+		}
+		res[i].Detail += ", " + c.label
+	}
+	return res
+}
+
+// prioritySuggestions are the priorities up to 10, higher priorities can still be typed.
+func prioritySuggestions() []Suggestion {
+	const highest = 10
+	res := make([]Suggestion, 0, highest-minPriority+1)
+	for p := minPriority; p <= highest; p++ {
+		res = append(res, Suggestion{Value: strconv.Itoa(p)})
+	}
+	return res
+}
+
+// projectLinks are links to each active project, they're loaded once per editor.
+func (m *Model) projectLinks() []Suggestion {
+	if m.projects == nil {
+		m.projects = []Suggestion{}
+		open := m.nd.ListProjects(
+			projects.FetchAllProjects(),
+			projects.WithFilter(projects.FilterByStatus(projects.Active, projects.Backlog, projects.Blocked)),
+			projects.WithSorters(), // empty defaults to alphabetical
+		)
+		for _, p := range open {
+			m.projects = append(m.projects, Suggestion{fmt.Sprintf("[[%s]]", p.Name()), string(p.Status())})
+		}
+	}
+	return m.projects
+}
+
+// tags are the #tags already used by tasks in the workspace, most used first. They're loaded once per editor.
+func (m *Model) tags() []Suggestion {
+	if m.tagged == nil {
+		counts := make(map[string]int)
+		for _, t := range m.nd.ListTasks(tasks.FetchAllTasks()) {
+			for _, word := range strings.Fields(t.Name()) {
+				if len(word) > 1 && strings.HasPrefix(word, "#") && !strings.HasPrefix(word, "##") {
+					counts[word]++
+				}
+			}
+		}
+		m.tagged = []Suggestion{}
+		for tag, n := range counts {
+			m.tagged = append(m.tagged, Suggestion{tag, fmt.Sprintf("%d tasks", n)})
+		}
+		slices.SortFunc(m.tagged, func(a, b Suggestion) int {
+			if n := counts[b.Value] - counts[a.Value]; n != 0 {
+				return n
+			}
+			return strings.Compare(a.Value, b.Value)
+		})
+	}
+	return m.tagged
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskeditor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	priorities := []Suggestion{{Value: "1"}, {Value: "2"}, {Value: "10"}}
+	tags := []Suggestion{{Value: "#work"}, {Value: "#workshop"}, {Value: "#home"}}
+	links := []Suggestion{{Value: "[[Alpha]]"}, {Value: "[[Alpha Two]]"}}
+
+	tests := []struct {
+		name        string
+		suggestions []Suggestion
+		typed       string
+		prefix      string
+		want        []Suggestion
+	}{
+		{name: "prefix", suggestions: priorities, typed: "", want: priorities},
+		{name: "exact match with longer matches", suggestions: priorities, typed: "1", want: []Suggestion{{Value: "10"}}},
+		{name: "only match is exact", suggestions: priorities, typed: "10", want: nil},
+		{name: "tag that prefixes another", suggestions: tags, typed: "#work", want: []Suggestion{{Value: "#workshop"}}},
+		{name: "link prefix", suggestions: links, typed: "alpha", prefix: "[[", want: links},
+		{name: "detail", suggestions: everyPhrases, typed: "fortnight", want: []Suggestion{{"2 weeks", "fortnightly"}}},
+		{name: "case insensitive", suggestions: tags, typed: "#HO", want: []Suggestion{{Value: "#home"}}},
+		{name: "no match", suggestions: tags, typed: "#x", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&Model{}).filter(tt.suggestions, tt.typed, tt.prefix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskeditor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/dates"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+// Diagnostic is a problem with part of the task text, Start and End are rune offsets into the text.
+type Diagnostic struct {
+	Start   int
+	End     int
+	Message string
+}
+
+// minPriority is the lowest priority, the tasks parser only reads digits so there are no negative priorities.
+const minPriority = 0
+
+type fieldKind int
+
+const (
+	dateField fieldKind = iota
+	completedField
+	priorityField
+	everyField
+)
+
+// fieldKinds are the keys the task parser understands, sched is expanded to scheduled along with relative dates.
+var fieldKinds = map[string]fieldKind{
+	"due":       dateField,
+	"d":         dateField,
+	"scheduled": dateField,
+	"sched":     dateField,
+	"s":         dateField,
+	"completed": completedField,
+	"priority":  priorityField,
+	"p":         priorityField,
+	"every":     everyField,
+	"e":         everyField,
+}
+
+// token is a whitespace separated word of the task text.
+type token struct {
+	start, end int // rune offsets
+	text       string
+}
+
+func tokenize(text string) []token {
+	var res []token
+	start := -1
+	runes := []rune(text)
+	for i, r := range runes {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				res = append(res, token{start, i, string(runes[start:i])})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		res = append(res, token{start, len(runes), string(runes[start:])})
+	}
+	return res
+}
+
+// diagnose checks each key:value field of the task text. Words that look like a field but use an unknown key are
+// reported too as they are most likely typos, urls (anything with a value starting //) are left alone.
+func diagnose(text string, now time.Time, weekStart time.Weekday, cal dates.Calendar) []Diagnostic {
+	var res []Diagnostic
+	runes := []rune(text)
+	tokens := tokenize(text)
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		key, value, ok := strings.Cut(tok.text, ":")
+		if !ok || key == "" || !isWord(key) {
+			continue
+		}
+		kind, known := fieldKinds[key]
+		if !known {
+			if value != "" && !strings.HasPrefix(value, "//") {
+				res = append(res, Diagnostic{tok.start, tok.end, fmt.Sprintf("%s is not a field, use due, scheduled, priority, every or completed", key)})
+			}
+			continue
+		}
+		if value == "" {
+			res = append(res, Diagnostic{tok.start, tok.end, fmt.Sprintf("%s is missing a value", key)})
+			continue
+		}
+
+		switch kind {
+		case dateField:
+			if _, ok := dates.Parse(value, now, weekStart, cal); !ok {
+				res = append(res, Diagnostic{tok.start, tok.end, fmt.Sprintf("%q is not a date", value)})
+			}
+		case completedField:
+			if _, err := time.Parse(dates.Layout, value); err != nil {
+				res = append(res, Diagnostic{tok.start, tok.end, fmt.Sprintf("completed must be an ISO date (2006-01-02), not %q", value)})
+			}
+		case priorityField:
+			if _, err := strconv.Atoi(value); err != nil || strings.TrimLeft(value, "0123456789") != "" {
+				res = append(res, Diagnostic{tok.start, tok.end, fmt.Sprintf("priority must be a whole number of %d or more", minPriority)})
+			}
+		case everyField:
+			// Rules can span several words (every:2 weeks) so parse the rest of the text and skip what was used
+			every, err := tasks.NewEvery(string(runes[tok.start:]))
+			if err != nil {
+				res = append(res, Diagnostic{tok.start, tok.end, fmt.Sprintf("%q is not a recurrence rule", value)})
+				continue
+			}
+			end := tok.start + len([]rune(key)) + 1 + len([]rune(every.String()))
+			for i+1 < len(tokens) && tokens[i+1].end <= end {
+				i++
+			}
+			if tokens[i].end != end {
+				res = append(res, Diagnostic{tok.start, tokens[i].end, fmt.Sprintf("%q is not a recurrence rule", string(runes[tok.start+len([]rune(key))+1:tokens[i].end]))})
+				continue
+			}
+			if len(notedown.Occurrences(every, now, 1)) == 0 {
+				res = append(res, Diagnostic{tok.start, end, fmt.Sprintf("every:%s never repeats", every.String())})
+			}
+		}
+	}
+	return res
+}
+
+func isWord(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskeditor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/notedownorg/task/pkg/dates"
+)
+
+func TestDiagnose(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 4, 5, 0, time.Local)
+	tests := []struct {
+		in   string
+		want []string // the underlined text of each diagnostic
+	}{
+		{"buy milk due:tomorrow p:2 every:2 weeks", nil},
+		{"read https://example.com at 10:30 note: later", nil},
+		{"buy milk du:tomorrow", []string{"du:tomorrow"}},
+		{"buy milk due:someday s:", []string{"due:someday", "s:"}},
+		{"buy milk completed:tomorrow", []string{"completed:tomorrow"}},
+		{"buy milk p:11", nil},
+		{"buy milk p:-1 priority:x", []string{"p:-1", "priority:x"}},
+		{"buy milk p:+1", []string{"p:+1"}},
+		{"buy milk every:fortnight", []string{"every:fortnight"}},
+		{"buy milk every:dayz due:fri", []string{"every:dayz"}},
+		{"buy milk every:31 feb", []string{"every:31 feb"}},
+		{"buy milk every:mon tue p:1.5", []string{"p:1.5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := diagnose(tt.in, now, time.Monday, dates.Calendar{})
+			if len(got) != len(tt.want) {
+				t.Fatalf("diagnose(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			for i, d := range got {
+				if text := string([]rune(tt.in)[d.Start:d.End]); text != tt.want[i] {
+					t.Errorf("diagnostic %d underlines %q, want %q", i, text, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
 	"github.com/notedownorg/task/pkg/components/pill"
 	"github.com/notedownorg/task/pkg/context"
@@ -39,8 +40,17 @@ type Fields struct {
 	Priority  *int
 	Every     *tasks.Every
 	Name      string
+
+	// Occurrences are the next dates matching Every, counted from the due or scheduled date.
+	Occurrences []time.Time
+
+	// Diagnostics are the problems found in the task text, submitting is blocked until they're fixed.
+	Diagnostics []Diagnostic
 }
 
+// previewOccurrences is the number of upcoming occurrences listed for a recurring task.
+const previewOccurrences = 5
+
 func NewFields(ctx *context.ProgramContext) *Fields {
 	return &Fields{
 		ctx: ctx,
@@ -76,8 +86,34 @@ func (f *Fields) View() string {
 		fields = append(fields, pill.New(theme.Pink, theme.TextCursor).Render(" "+f.Completed.Format("2006-01-02")))
 	}
 
-	return f.base.NewStyle().
-		Render(strings.Join(fields, "  "))
+	view := strings.Join(fields, "  ")
+	// A rule that never repeats is already one of the diagnostics
+	if f.Every != nil && (len(f.Occurrences) > 0 || len(f.Diagnostics) == 0) {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, "", f.occurrencesView())
+	}
+	if len(f.Diagnostics) > 0 {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, "", f.diagnosticsView())
+	}
+	return f.base.NewStyle().Render(view)
+}
+
+func (f *Fields) occurrencesView() string {
+	if len(f.Occurrences) == 0 {
+		return lipgloss.NewStyle().Foreground(f.ctx.Theme.Red).Render("󰕇  every rule never repeats")
+	}
+	dates := make([]string, len(f.Occurrences))
+	for i, date := range f.Occurrences {
+		dates[i] = date.Format("Mon 2006-01-02")
+	}
+	return lipgloss.NewStyle().Foreground(f.ctx.Theme.TextFaint).Render("󰕇  next " + strings.Join(dates, ", "))
+}
+
+func (f *Fields) diagnosticsView() string {
+	lines := make([]string, len(f.Diagnostics))
+	for i, d := range f.Diagnostics {
+		lines[i] = "  " + d.Message
+	}
+	return lipgloss.NewStyle().Foreground(f.ctx.Theme.Red).Render(strings.Join(lines, "\n"))
 }
 
 var statusMap = map[tasks.Status]string{
@@ -14,20 +14,84 @@
 
 package taskeditor
 
-import "github.com/charmbracelet/bubbles/v2/key"
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
 
 type KeyMap struct {
 	ToggleFocus key.Binding
 	Submit      key.Binding
+	Retry       key.Binding
+
+	// Down and Up move through the suggestions while typing the task and the files while picking its location
+	Down             key.Binding
+	Up               key.Binding
+	AcceptSuggestion key.Binding
+	PrevPlacement    key.Binding
+	NextPlacement    key.Binding
 }
 
 var DefaultKeyMap = KeyMap{
 	ToggleFocus: key.NewBinding(
 		key.WithKeys("tab"),
-		key.WithHelp("tab", "toggle focus between fields"),
+		key.WithHelp("tab", "move focus between status, text and location"),
 	),
 	Submit: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "submit the task"),
 	),
+	Retry: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "retry a failed submit"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓/ctrl+n", "next suggestion or file"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "previous suggestion or file"),
+	),
+	AcceptSuggestion: key.NewBinding(
+		key.WithKeys("ctrl+y"),
+		key.WithHelp("ctrl+y", "accept the suggestion"),
+	),
+	PrevPlacement: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous place in the file"),
+	),
+	NextPlacement: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next place in the file"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"ToggleFocus": &k.ToggleFocus,
+		"Submit":      &k.Submit,
+		"Retry":       &k.Retry,
+
+		"Down":             &k.Down,
+		"Up":               &k.Up,
+		"AcceptSuggestion": &k.AcceptSuggestion,
+		"PrevPlacement":    &k.PrevPlacement,
+		"NextPlacement":    &k.NextPlacement,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.ToggleFocus}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Submit, k.ToggleFocus, k.Retry},
+		{k.Down, k.Up, k.AcceptSuggestion},
+		{k.PrevPlacement, k.NextPlacement},
+	}
 }
@@ -16,24 +16,68 @@ package taskeditor
 
 import (
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/notedown/pkg/fileserver/writer"
+	"github.com/notedownorg/notedown/pkg/providers/daily"
+	"github.com/notedownorg/notedown/pkg/providers/projects"
 	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/fuzzy"
 	"github.com/notedownorg/task/pkg/model"
+	"github.com/notedownorg/task/pkg/notedown"
 )
 
+// maxFiles is the number of matching files listed while the location is focused.
+const maxFiles = 6
+
+// Location is the file and line a task is written to. While focused the file can be picked from the workspace's
+// daily notes, projects and other markdown files, along with where in the file the task goes.
 type Location struct {
 	base model.Base
 
 	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	// home is where the task was when the editor opened, a line of -1 means it's being added to the end
+	home     string
+	homeLine int
+
+	focused bool
+	filter  textinput.Model
+
+	files    []file // loaded the first time the location is focused
+	matches  []int
+	cursor   int
+	selected file
+
+	placements []placement
+	placed     int
+}
+
+type file struct {
+	path string
+	kind string
+}
 
-	file string
-	line int
+// placement is a line of the selected file the task can be written at.
+type placement struct {
+	line  int
+	label string
 }
 
-func NewLocation(ctx *context.ProgramContext) *Location {
+func NewLocation(ctx *context.ProgramContext, nd notedown.Client) *Location {
+	filter := textinput.New()
+	filter.Prompt = "󰍉 "
+	filter.Placeholder = "filter daily notes, projects and files"
 	return &Location{
-		ctx: ctx,
+		ctx:    ctx,
+		nd:     nd,
+		filter: filter,
 	}
 }
 
@@ -42,25 +86,190 @@ func (l *Location) Init() (tea.Model, tea.Cmd) {
 }
 
 func (l *Location) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	return l, nil
+	if !l.focused {
+		return l, nil
+	}
+	value := l.filter.Value()
+	var cmd tea.Cmd
+	l.filter, cmd = l.filter.Update(msg)
+	if l.filter.Value() != value {
+		l.match()
+	}
+	return l, cmd
 }
 
-func (l *Location) SetLocation(file string, line int) *Location {
-	l.file = file
-	l.line = line
+// SetLocation sets where the task starts out, a line of -1 adds it to the end of the file.
+func (l *Location) SetLocation(path string, line int) *Location {
+	l.home, l.homeLine = path, line
+	l.selected = file{path: path}
+	l.place()
 	return l
 }
 
+// File is the path of the selected file.
+func (l Location) File() string {
+	return l.selected.path
+}
+
+// Line is the line of the selected file the task is written at.
+func (l Location) Line() int {
+	if len(l.placements) == 0 {
+		return writer.AT_END
+	}
+	return l.placements[l.placed].line
+}
+
+// Moved reports whether a different file or line than where the task started has been picked.
+func (l Location) Moved() bool {
+	return l.File() != l.home || l.Line() != l.homeLine && l.homeLine > 0
+}
+
+func (l *Location) Focus() {
+	l.focused = true
+	l.filter.Focus()
+	if l.files == nil {
+		l.load()
+	}
+	l.match()
+}
+
+func (l *Location) Blur() {
+	l.focused = false
+	l.filter.Blur()
+}
+
+// load lists the daily notes, newest first, then projects then any other markdown files.
+func (l *Location) load() {
+	kinds := make(map[string]string)
+	for _, d := range l.nd.ListDailyNotes(daily.FetchAllNotes()) {
+		kinds[d.Path()] = "daily note"
+	}
+	for _, p := range l.nd.ListProjects(projects.FetchAllProjects()) {
+		kinds[p.Path()] = "project"
+	}
+	var notes, project, other []file
+	for _, path := range l.nd.ListDocuments() {
+		switch kinds[path] {
+		case "daily note":
+			notes = append(notes, file{path, kinds[path]})
+		case "project":
+			project = append(project, file{path, kinds[path]})
+		default:
+			other = append(other, file{path, ""})
+		}
+	}
+	slices.Reverse(notes)
+	l.files = append(append(notes, project...), other...)
+}
+
+func (l *Location) match() {
+	paths := make([]string, len(l.files))
+	for i, f := range l.files {
+		paths[i] = f.path
+	}
+	l.matches = fuzzy.Filter(l.filter.Value(), paths)
+	l.cursor = slices.IndexFunc(l.matches, func(i int) bool { return l.files[i].path == l.selected.path })
+	if l.cursor < 0 {
+		l.cursor = 0
+		l.pick()
+	}
+}
+
+func (l *Location) MoveUp() {
+	if l.cursor > 0 {
+		l.cursor--
+		l.pick()
+	}
+}
+
+func (l *Location) MoveDown() {
+	if l.cursor < len(l.matches)-1 {
+		l.cursor++
+		l.pick()
+	}
+}
+
+func (l *Location) NextPlacement() {
+	l.placed = (l.placed + 1) % len(l.placements)
+}
+
+func (l *Location) PrevPlacement() {
+	l.placed = (l.placed - 1 + len(l.placements)) % len(l.placements)
+}
+
+// pick selects the file under the cursor.
+func (l *Location) pick() {
+	if len(l.matches) == 0 {
+		return
+	}
+	l.selected = l.files[l.matches[l.cursor]]
+	l.place()
+}
+
+// place lists where the task can go in the selected file, where it already is first when editing.
+func (l *Location) place() {
+	l.placements = l.placements[:0]
+	l.placed = 0
+	if l.selected.path == l.home && l.homeLine > 0 {
+		l.placements = append(l.placements, placement{l.homeLine, fmt.Sprintf("Line %d", l.homeLine)})
+	}
+	l.placements = append(l.placements,
+		placement{writer.AT_END, "At End"},
+		placement{writer.AT_BEGINNING, "At Start"},
+	)
+	if l.selected.path == "" {
+		return
+	}
+	headings, err := l.nd.ListHeadings(l.selected.path)
+	if err != nil {
+		slog.Warn("failed to read headings", "path", l.selected.path, "error", err)
+		return
+	}
+	for _, h := range headings {
+		l.placements = append(l.placements, placement{h.End + 1, fmt.Sprintf("Under %s %s", strings.Repeat("#", h.Level), h.Text)})
+	}
+}
+
 func (l *Location) View() string {
-	file := l.file
-	line := fmt.Sprintf("%d", l.line)
-	if file == "" {
-		file = "<select>"
-		line = "At End"
+	path := l.selected.path
+	if path == "" {
+		path = "<select>"
+	}
+	line := "At End"
+	if len(l.placements) > 0 {
+		line = l.placements[l.placed].label
 	}
-	if l.line == -1 {
-		line = "At End"
+	summary := fmt.Sprintf("  %s 󰁕 %s", path, line)
+	if !l.focused {
+		return l.base.NewStyle().Render(summary)
+	}
+
+	faint := lipgloss.NewStyle().Foreground(l.ctx.Theme.TextFaint)
+	start := 0
+	if l.cursor >= maxFiles {
+		start = l.cursor - maxFiles + 1
 	}
-	return l.base.NewStyle().
-		Render(fmt.Sprintf("  %s 󰁕 %s", file, line))
+	end := min(start+maxFiles, len(l.matches))
+	rows := make([]string, 0, maxFiles)
+	for i := start; i < end; i++ {
+		f := l.files[l.matches[i]]
+		style := lipgloss.NewStyle().Foreground(l.ctx.Theme.Text)
+		if i == l.cursor {
+			style = style.Foreground(l.ctx.Theme.TextCursor).Background(l.ctx.Theme.Blue)
+		}
+		rows = append(rows, style.Render(" "+f.path+" ")+" "+faint.Render(f.kind))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, faint.Render(" no matching files"))
+	}
+
+	placement := faint.Render("[ ") + lipgloss.NewStyle().Foreground(l.ctx.Theme.Text).Render(line) + faint.Render(" ]")
+	return l.base.NewStyle().Render(lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Foreground(l.ctx.Theme.Text).Render(summary),
+		"",
+		l.filter.View(),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		placement,
+	))
 }
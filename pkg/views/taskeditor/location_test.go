@@ -0,0 +1,46 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskeditor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+func TestLoad(t *testing.T) {
+	nd, _ := testenv.Workspace(t, notedown.NewClient, map[string]string{
+		"daily/2024-06-04.md":   "---\ntype: daily\n---\n",
+		"journal/2024-06-05.md": "---\ntype: daily\n---\n",
+		"projects/Alpha.md":     "---\ntype: project\nname: Alpha\nstatus: active\n---\n",
+		"notes.md":              "# Notes\n",
+	})
+	l := testenv.View(time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC), func(ctx *context.ProgramContext) *Location { return NewLocation(ctx, nd) })
+	l.load()
+
+	want := []file{
+		{"journal/2024-06-05.md", "daily note"},
+		{"daily/2024-06-04.md", "daily note"},
+		{"projects/Alpha.md", "project"},
+		{"notes.md", ""},
+	}
+	if !reflect.DeepEqual(l.files, want) {
+		t.Errorf("files = %v, want %v", l.files, want)
+	}
+}
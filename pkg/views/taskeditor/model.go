@@ -18,7 +18,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/help"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
@@ -34,6 +34,17 @@ const (
 	editing
 )
 
+// HandleNew opens the editor to add a task to today's daily note, it is unbound by default so is mainly reached
+// through the command palette.
+func HandleNew(nd notedown.Client) context.GlobalKeyHandler {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.AddTask,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
+			return ctx.Navigate(New(ctx, nd, WithAddToDaily(tasks.Todo, "", ctx.Now())))
+		},
+	}
+}
+
 type Model struct {
 	ctx *context.ProgramContext
 	nd  notedown.Client
@@ -44,6 +55,13 @@ type Model struct {
 
 	keyMap KeyMap
 
+	// failed is the error of the last submit, while set the submit can be retried
+	failed error
+
+	// projects and tagged are the workspace's project links and tags, loaded the first time they're completed
+	projects []Suggestion
+	tagged   []Suggestion
+
 	status   *Status
 	text     *Text
 	fields   *Fields
@@ -60,34 +78,87 @@ func New(ctx *context.ProgramContext, nd notedown.Client, mode Mode) *Model {
 		keyMap: DefaultKeyMap,
 	}
 	mode(m)
+	m.footer.SetHelp(m.keyMap)
 	return m
 }
 
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
 func (m *Model) Init() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// CapturingInput reports whether key presses are being typed into the task text.
+func (m *Model) CapturingInput() bool {
+	return m.text.ti.Focused() || m.location.focused
+}
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	actions := []context.Action{
+		{Binding: m.keyMap.ToggleFocus, Run: func() (tea.Model, tea.Cmd) { m.toggleFocus(); return nil, nil }},
+		{Binding: m.keyMap.Submit, Run: m.submit},
+	}
+	if m.failed != nil {
+		actions = append(actions, context.Action{Binding: m.keyMap.Retry, Run: m.retry})
+	}
+	if m.text.Suggesting() {
+		actions = append(actions,
+			context.Action{Binding: m.keyMap.Down, Run: func() (tea.Model, tea.Cmd) { m.text.NextSuggestion(); return nil, nil }},
+			context.Action{Binding: m.keyMap.Up, Run: func() (tea.Model, tea.Cmd) { m.text.PrevSuggestion(); return nil, nil }},
+			context.Action{Binding: m.keyMap.AcceptSuggestion, Run: func() (tea.Model, tea.Cmd) { m.text.AcceptSuggestion(); return nil, nil }},
+		)
+	}
+	if m.location.focused {
+		actions = append(actions,
+			context.Action{Binding: m.keyMap.Down, Run: func() (tea.Model, tea.Cmd) { m.location.MoveDown(); return nil, nil }},
+			context.Action{Binding: m.keyMap.Up, Run: func() (tea.Model, tea.Cmd) { m.location.MoveUp(); return nil, nil }},
+			context.Action{Binding: m.keyMap.PrevPlacement, Run: func() (tea.Model, tea.Cmd) { m.location.PrevPlacement(); return nil, nil }},
+			context.Action{Binding: m.keyMap.NextPlacement, Run: func() (tea.Model, tea.Cmd) { m.location.NextPlacement(); return nil, nil }},
+		)
+	}
+	return actions
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	// Handle view level key presses
+	handled := false
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, m.keyMap.ToggleFocus):
-			m.toggleFocus()
-		case key.Matches(msg, m.keyMap.Submit):
-			return m.submit()
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+			handled = true
 		}
 	}
 
-	// Handle component events
-	m.status.Update(msg)
-	m.text.Update(msg)
+	// Handle component events, keys that ran an action aren't typed into the text or location filter
+	if !handled {
+		m.status.Update(msg)
+		m.text.Update(msg)
+		m.location.Update(msg)
+	}
 
 	// Attempt to parse the full task and use the response to update the fields subcomponent
 	m.parseTask()
 
+	// Suggest completions for whatever is being typed
+	if _, ok := msg.(tea.KeyMsg); ok {
+		if m.text.ti.Focused() {
+			m.text.Suggest(m.complete(m.text.Value(), m.text.Cursor()))
+		} else {
+			m.text.Suggest(0, 0, nil)
+		}
+	}
+
 	// Handle program level key presses and events
 	model, command := m.ctx.Update(msg)
 	if model != nil { // if model is not nil we're navigating to a new view
@@ -164,12 +235,17 @@ func (m *Model) View() string {
 	return lipgloss.NewStyle().Padding(0, horizontalPadding).Render(panel)
 }
 
+// toggleFocus moves the focus from the status to the text to the location and back to the status.
 func (m *Model) toggleFocus() {
-	if m.status.focused {
+	switch {
+	case m.status.focused:
 		m.status = m.status.Blur()
 		m.text = m.text.Focus()
-	} else {
-		m.status = m.status.Focus()
+	case m.text.ti.Focused():
 		m.text = m.text.Blur()
+		m.location.Focus()
+	default:
+		m.location.Blur()
+		m.status = m.status.Focus()
 	}
 }
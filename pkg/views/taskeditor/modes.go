@@ -39,7 +39,7 @@ func WithAddToDaily(status tasks.Status, text string, date time.Time) Mode {
 		m.text = NewText(m.ctx).SetValue(text)
 		m.footer = statusbar.New(m.ctx, statusbar.NewMode("add task", statusbar.ActionCreate), m.nd)
 		m.fields = NewFields(m.ctx)
-		m.location = NewLocation(m.ctx)
+		m.location = NewLocation(m.ctx, m.nd)
 		m.location.SetLocation(d.Path(), -1) // At end
 		m.text.SetCursor(0)
 		m.parseTask()
@@ -54,7 +54,7 @@ func WithAddToProject(status tasks.Status, text string, project projects.Project
 		m.text = NewText(m.ctx).SetValue(text)
 		m.footer = statusbar.New(m.ctx, statusbar.NewMode("add task", statusbar.ActionCreate), m.nd)
 		m.fields = NewFields(m.ctx)
-		m.location = NewLocation(m.ctx).SetLocation(project.Path(), -1) // At end
+		m.location = NewLocation(m.ctx, m.nd).SetLocation(project.Path(), -1) // At end
 		m.text.SetCursor(0)
 		m.parseTask()
 	}
@@ -69,7 +69,7 @@ func WithEdit(task tasks.Task, date time.Time) Mode {
 		m.text = NewText(m.ctx).SetValue(task.Body())
 		m.footer = statusbar.New(m.ctx, statusbar.NewMode("edit task", statusbar.ActionEdit), m.nd)
 		m.fields = NewFields(m.ctx)
-		m.location = NewLocation(m.ctx).SetLocation(task.Path(), task.Line())
+		m.location = NewLocation(m.ctx, m.nd).SetLocation(task.Path(), task.Line())
 		m.text.SetCursor(0)
 		m.parseTask()
 	}
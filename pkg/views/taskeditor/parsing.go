@@ -21,20 +21,28 @@ import (
 
 	"github.com/a-h/parse"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/dates"
+	"github.com/notedownorg/task/pkg/notedown"
 )
 
 func (m *Model) parseTask() {
-	// Build it into a task string and parse it
-	parser := tasks.ParseTask("", "", time.Now())
-	in := parse.NewInput(fmt.Sprintf("- [%s] %s", m.status.Value(), m.text.Value()))
-	task, ok, _ := parser.Parse(in)
+	// Build it into a task string and parse it, relative dates are expanded first as the parser only reads ISO dates
+	parser := tasks.ParseTask("", "", m.ctx.Now())
+	text := dates.Expand(m.text.Value(), m.ctx.Now(), m.ctx.WeekStart(), m.ctx.Calendar())
+	in := parse.NewInput(fmt.Sprintf("- [%s] %s", m.status.Value(), text))
+	task, ok, err := parser.Parse(in)
 
-	// TODO: Better parsing is probably needed for the IsValid field to be useful.
-	if !ok {
-		m.text.IsValid = false
+	// The parser quietly leaves anything it can't read in the name so check the fields ourselves
+	m.text.Diagnostics = diagnose(m.text.Value(), m.ctx.Now(), m.ctx.WeekStart(), m.ctx.Calendar())
+	if !ok || err != nil {
+		// The fields are left as they were so they can't be saved, mark the whole text instead
+		m.text.Diagnostics = append(m.text.Diagnostics, Diagnostic{0, len([]rune(m.text.Value())), "the task can't be read"})
+	}
+	m.fields.Diagnostics = m.text.Diagnostics
+	m.text.IsValid = len(m.text.Diagnostics) == 0
+	if !ok || err != nil {
 		return
 	}
-	m.text.IsValid = true
 
 	// If it parses, update the fields
 	m.fields.Status = task.Status()
@@ -44,6 +52,24 @@ func (m *Model) parseTask() {
 	m.fields.Priority = task.Priority()
 	m.fields.Every = task.Every()
 	m.fields.Completed = m.computeCompleted(task)
+
+	// A rule that never repeats is as good as a typo
+	m.fields.Occurrences = nil
+	if task.Every() != nil {
+		m.fields.Occurrences = notedown.Occurrences(*task.Every(), m.recurrenceStart(task), previewOccurrences)
+		m.text.IsValid = m.text.IsValid && len(m.fields.Occurrences) > 0
+	}
+}
+
+// recurrenceStart is the date occurrences are counted from, the due or scheduled date falling back to today.
+func (m *Model) recurrenceStart(task tasks.Task) time.Time {
+	switch {
+	case task.Due() != nil:
+		return *task.Due()
+	case task.Scheduled() != nil:
+		return *task.Scheduled()
+	}
+	return m.ctx.Now()
 }
 
 func (m *Model) computeCompleted(task tasks.Task) *time.Time {
@@ -15,6 +15,9 @@
 package taskeditor
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
@@ -29,6 +32,14 @@ type Text struct {
 
 	ti      textinput.Model
 	IsValid bool
+
+	// Diagnostics are underlined beneath the input.
+	Diagnostics []Diagnostic
+
+	// suggestions complete the text between the start and end rune offsets, they're shown in a popup under the input.
+	suggestions []Suggestion
+	start, end  int
+	selected    int
 }
 
 func NewText(ctx *context.ProgramContext) *Text {
@@ -90,8 +101,98 @@ func (t *Text) SetCursor(i int) {
 func (s *Text) View() string {
 	valid := lipgloss.NewStyle().Foreground(s.ctx.Theme.Green).Render("✓")
 	invalid := lipgloss.NewStyle().Foreground(s.ctx.Theme.Red).Render("✗")
+	view := s.ti.View() + " " + invalid
 	if s.IsValid {
-		return s.ti.View() + " " + valid
+		view = s.ti.View() + " " + valid
+	}
+	if underline := s.underline(); underline != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, underline)
+	}
+	if len(s.suggestions) > 0 {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, s.popup())
+	}
+	return view
+}
+
+// Suggest replaces the suggestions, the selection is kept if they haven't changed.
+func (t *Text) Suggest(start, end int, suggestions []Suggestion) {
+	if t.start != start || !slices.Equal(t.suggestions, suggestions) {
+		t.selected = 0
+	}
+	t.suggestions, t.start, t.end = suggestions, start, end
+}
+
+func (t Text) Suggesting() bool {
+	return len(t.suggestions) > 0
+}
+
+func (t *Text) NextSuggestion() {
+	t.selected = (t.selected + 1) % len(t.suggestions)
+}
+
+func (t *Text) PrevSuggestion() {
+	t.selected = (t.selected - 1 + len(t.suggestions)) % len(t.suggestions)
+}
+
+// AcceptSuggestion replaces the text being completed with the selected suggestion.
+func (t *Text) AcceptSuggestion() {
+	value := []rune(t.ti.Value())
+	if len(t.suggestions) == 0 || t.end > len(value) {
+		return
+	}
+	accepted := []rune(t.suggestions[t.selected].Value)
+	t.ti.SetValue(string(value[:t.start]) + string(accepted) + string(value[t.end:]))
+	t.ti.SetCursor(t.start + len(accepted))
+	t.suggestions = nil
+}
+
+// popup lists the suggestions under the start of the text they complete.
+func (s *Text) popup() string {
+	faint := lipgloss.NewStyle().Foreground(s.ctx.Theme.TextFaint)
+	width := 0
+	for _, suggestion := range s.suggestions {
+		width = max(width, lipgloss.Width(suggestion.Value))
+	}
+	lines := make([]string, len(s.suggestions))
+	for i, suggestion := range s.suggestions {
+		value := lipgloss.NewStyle().Width(width).Render(suggestion.Value)
+		if i == s.selected {
+			value = lipgloss.NewStyle().Width(width).Background(s.ctx.Theme.Yellow).Foreground(s.ctx.Theme.TextCursor).Render(suggestion.Value)
+		}
+		lines[i] = value + "  " + faint.Render(suggestion.Detail)
+	}
+	indent := 0
+	if value := []rune(s.ti.Value()); lipgloss.Width(string(value)) < s.ti.Width && s.start <= len(value) {
+		indent = lipgloss.Width(string(value[:s.start]))
+	}
+	return lipgloss.NewStyle().
+		MarginLeft(indent).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.ctx.Theme.BorderFaint).
+		Render(strings.Join(lines, "\n"))
+}
+
+// underline marks the diagnostics beneath the text they refer to. It is left out when the text is wider than the
+// input as the offsets no longer line up once it scrolls.
+func (s *Text) underline() string {
+	value := []rune(s.ti.Value())
+	if len(s.Diagnostics) == 0 || lipgloss.Width(string(value)) >= s.ti.Width {
+		return ""
+	}
+	marks := make([]bool, len(value))
+	for _, d := range s.Diagnostics {
+		for i := d.Start; i < d.End && i < len(marks); i++ {
+			marks[i] = true
+		}
+	}
+	var b strings.Builder
+	for i, r := range value {
+		c := " "
+		if marks[i] {
+			c = "~"
+		}
+		b.WriteString(strings.Repeat(c, lipgloss.Width(string(r))))
 	}
-	return s.ti.View() + " " + invalid
+	return lipgloss.NewStyle().Foreground(s.ctx.Theme.Red).Render(strings.TrimRight(b.String(), " "))
 }
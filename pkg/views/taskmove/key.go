@@ -0,0 +1,62 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskmove
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+type KeyMap struct {
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	Move       key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	CursorUp: key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "move cursor up"),
+	),
+	CursorDown: key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "move cursor down"),
+	),
+	Move: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "move the tasks to the selected project"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"CursorUp":   &k.CursorUp,
+		"CursorDown": &k.CursorDown,
+		"Move":       &k.Move,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.CursorUp, k.CursorDown}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.CursorUp, k.CursorDown, k.Move},
+	}
+}
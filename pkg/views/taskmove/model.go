@@ -0,0 +1,286 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskmove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/notedownorg/notedown/pkg/providers/projects"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/fuzzy"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const (
+	width      = 60
+	maxVisible = 12
+)
+
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	keyMap KeyMap
+
+	originals []tasks.Task
+
+	// moving is set while the tasks are being moved so they aren't moved twice
+	moving bool
+
+	input    textinput.Model
+	projects []projects.Project // the open projects the tasks can be moved to, see load
+	matches  []int              // indexes into projects, best match first
+	cursor   int
+
+	footer *statusbar.Model
+}
+
+// New moves each of the given tasks to the end of the project picked from a list of the open projects.
+func New(ctx *context.ProgramContext, nd notedown.Client, ts ...tasks.Task) *Model {
+	input := textinput.New()
+	input.Prompt = "󰉋 "
+	input.Placeholder = "Type to search projects"
+	input.Focus()
+
+	m := &Model{
+		ctx:       ctx,
+		nd:        nd,
+		keyMap:    DefaultKeyMap,
+		originals: ts,
+		input:     input,
+		footer:    statusbar.New(ctx, statusbar.NewMode(modeText(len(ts)), statusbar.ActionEdit), nd).SetHelp(DefaultKeyMap),
+	}
+	m.load()
+	return m
+}
+
+// load lists the open projects, leaving out the project the tasks are in when they all come from the same one. Tasks
+// from several files can go to any of them as tasks already in the picked project are left where they are.
+func (m *Model) load() {
+	from := m.originals[0].Path()
+	for _, t := range m.originals[1:] {
+		if t.Path() != from {
+			from = ""
+			break
+		}
+	}
+	open := m.nd.ListProjects(
+		projects.FetchAllProjects(),
+		projects.WithFilter(projects.FilterByStatus(projects.Active, projects.Backlog, projects.Blocked)),
+		projects.WithSorters(), // empty defaults to alphabetical
+	)
+	m.projects = make([]projects.Project, 0, len(open))
+	for _, p := range open {
+		if p.Path() != from {
+			m.projects = append(m.projects, p)
+		}
+	}
+	m.filter()
+}
+
+// modeText describes the move in the statusbar mode.
+func modeText(n int) string {
+	if n == 1 {
+		return "move task"
+	}
+	return fmt.Sprintf("move %d tasks", n)
+}
+
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+// CapturingInput reports whether key presses are being typed into the search, which is always the case.
+func (m *Model) CapturingInput() bool {
+	return true
+}
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		{Binding: m.keyMap.CursorUp, Run: func() (tea.Model, tea.Cmd) { m.moveUp(); return nil, nil }},
+		{Binding: m.keyMap.CursorDown, Run: func() (tea.Model, tea.Cmd) { m.moveDown(); return nil, nil }},
+		{Binding: m.keyMap.Move, Run: m.move},
+	}
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		} else {
+			previous := m.input.Value()
+			m.input, cmd = m.input.Update(msg)
+			if m.input.Value() != previous {
+				m.filter()
+			}
+		}
+	case statusbar.WrittenEvent:
+		// Failures are reported here rather than as a program notice as the view stays open to retry them
+		if msg.From(m.footer) {
+			return m.written(msg)
+		}
+	}
+
+	// Handle program level key presses and events
+	model, command := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, tea.Batch(command, cmd)
+	}
+	cmd = tea.Batch(cmd, command)
+	return m, cmd
+}
+
+// move moves the tasks to the selected project in the background.
+func (m *Model) move() (tea.Model, tea.Cmd) {
+	if len(m.matches) == 0 || m.moving {
+		return nil, nil
+	}
+	m.moving = true
+	originals, path := m.originals, m.projects[m.matches[m.cursor]].Path()
+	return nil, m.footer.Write("move", len(originals), func() []notedown.TaskError {
+		return notedown.MoveTasks(m.nd, originals, path)
+	})
+}
+
+// written handles the result of a move, staying open with only the tasks that failed so they can be retried.
+func (m *Model) written(msg statusbar.WrittenEvent) (tea.Model, tea.Cmd) {
+	m.moving = false
+	if len(msg.Errs) > 0 {
+		m.footer.SetTaskErrors(msg.Verb, msg.Total, msg.Errs)
+		m.originals = make([]tasks.Task, 0, len(msg.Errs))
+		for _, err := range msg.Errs {
+			m.originals = append(m.originals, err.Task)
+		}
+		m.footer.SetMode(statusbar.NewMode(modeText(len(m.originals)), statusbar.ActionEdit))
+		m.load()
+		return m, nil
+	}
+	return m.ctx.Back(), nil
+}
+
+func (m *Model) filter() {
+	names := make([]string, len(m.projects))
+	for i, p := range m.projects {
+		names[i] = p.Name()
+	}
+	m.matches = fuzzy.Filter(m.input.Value(), names)
+	m.cursor = 0
+}
+
+func (m *Model) moveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+func (m *Model) moveDown() {
+	if m.cursor < len(m.matches)-1 {
+		m.cursor++
+	}
+}
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalMargin := 1
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth-horizontalPadding*2).
+		Margin(verticalMargin, 0).
+		View()
+
+	m.input.Width = width - lipgloss.Width(m.input.Prompt) - 1
+
+	// Keep the cursor within the visible window of matches
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.matches))
+
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint)
+	rows := make([]string, 0, maxVisible)
+	for i := start; i < end; i++ {
+		p := m.projects[m.matches[i]]
+		style := lipgloss.NewStyle().Foreground(m.ctx.Theme.Text)
+		if i == m.cursor {
+			style = style.Foreground(m.ctx.Theme.TextCursor).Background(m.ctx.Theme.Blue)
+		}
+		status := faint.Inherit(style).Render(string(p.Status()) + " ")
+		name := style.Width(width - lipgloss.Width(status)).Render(" " + p.Name())
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Left, name, status))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, faint.Render(" no other open projects"))
+	}
+
+	moving := m.originals[0].Name()
+	if len(m.originals) > 1 {
+		moving = fmt.Sprintf("%d tasks", len(m.originals))
+	}
+
+	top := lipgloss.NewStyle().
+		Margin(1, 3).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Top,
+			m.input.View(),
+			"",
+			lipgloss.JoinVertical(lipgloss.Top, rows...),
+			"",
+			faint.Render("moving "+moving+" to the end of the project"),
+		))
+
+	border := lipgloss.RoundedBorder()
+	var b strings.Builder
+	str := "Move-Task"
+	for i := len(str) + 2; i <= lipgloss.Width(top); i++ {
+		b.WriteString(lipgloss.RoundedBorder().Top)
+	}
+	b.WriteString(str)
+	border.Top = b.String()
+
+	form := lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(m.ctx.Theme.Yellow).
+		Render(top)
+
+	width := m.ctx.ScreenWidth - horizontalPadding*2
+	height := m.ctx.ScreenHeight - lipgloss.Height(footer)
+
+	dialog := lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, form)
+
+	panel := lipgloss.JoinVertical(lipgloss.Top, dialog, footer)
+
+	return lipgloss.NewStyle().Padding(0, horizontalPadding).Render(panel)
+}
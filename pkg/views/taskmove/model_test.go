@@ -0,0 +1,99 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskmove
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const project = "---\ntype: project\nname: %[1]s\nstatus: active\n---\n# %[1]s\n- [ ] Task in %[1]s\n"
+
+// newTestModel moves the tasks of the given projects, of A, B and C, it returns the root of the workspace.
+func newTestModel(t *testing.T, from ...string) (*Model, string) {
+	t.Helper()
+	files := make(map[string]string)
+	for _, name := range []string{"A", "B", "C"} {
+		files["projects/"+name+".md"] = fmt.Sprintf(project, name)
+	}
+	nd, root := testenv.Workspace(t, notedown.NewClient, files)
+	var ts []tasks.Task
+	for _, name := range from {
+		ts = append(ts, nd.ListTasks(tasks.FetchTasksForDocument("projects/"+name+".md"))...)
+	}
+	if len(ts) != len(from) {
+		t.Fatalf("found %d tasks, want %d", len(ts), len(from))
+	}
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC)
+	return testenv.View(now, func(ctx *context.ProgramContext) *Model { return New(ctx, nd, ts...) }), root
+}
+
+func names(m *Model) []string {
+	res := make([]string, 0, len(m.projects))
+	for _, p := range m.projects {
+		res = append(res, p.Name())
+	}
+	return res
+}
+
+func TestProjects(t *testing.T) {
+	tests := []struct {
+		name string
+		from []string
+		want []string
+	}{
+		{name: "one task", from: []string{"A"}, want: []string{"B", "C"}},
+		{name: "tasks from several projects", from: []string{"A", "B"}, want: []string{"A", "B", "C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(t, tt.from...)
+			if got := names(m); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("projects = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartialFailure(t *testing.T) {
+	m, root := newTestModel(t, "A", "B")
+	m.ctx.ScreenWidth, m.ctx.ScreenHeight = 120, 30
+	testenv.Write(t, root, "projects/B.md", fmt.Sprintf(project, "B")+"edited\n")
+
+	m.cursor = 2 // C
+	_, cmd := m.move()
+	m.Update(cmd())
+
+	if got, want := testenv.Read(t, root, "projects/C.md"), fmt.Sprintf(project, "C")+"- [ ] Task in A\n"; got != want {
+		t.Errorf("C.md = %q, want %q", got, want)
+	}
+	if len(m.originals) != 1 || m.originals[0].Name() != "Task in B" {
+		t.Fatalf("originals = %v, want the task in B", m.originals)
+	}
+	if view := m.View(); !strings.Contains(view, "MOVE TASK") {
+		t.Errorf("statusbar mode isn't for one task:\n%s", view)
+	}
+	if got, want := names(m), []string{"A", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("projects = %q, want %q", got, want)
+	}
+}
@@ -15,35 +15,101 @@
 package taskreschedule
 
 import (
+	"fmt"
 	"log/slog"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/notedown"
 )
 
 func (m *Model) submit(date time.Time) (tea.Model, tea.Cmd) {
-	opts := make([]tasks.TaskOption, 0)
-
-	// If scheduled date is set update it
-	if m.original.Scheduled() != nil {
-		opts = append(opts, tasks.WithScheduled(date))
-	}
-
-	// If due date is set update it
-	if m.original.Due() != nil {
-		opts = append(opts, tasks.WithDue(date))
+	if m.submitting != nil {
+		return nil, nil
 	}
+	m.submitting = &date
+	originals, target := m.originals, m.target
+	return nil, m.footer.Write("reschedule", len(originals), func() []notedown.TaskError {
+		return notedown.WriteTasks(m.nd, originals, func(t tasks.Task) error {
+			task := RescheduleDates(t, date, target)
+			slog.Debug("submitting rescheduled task", "identifier", task.Identifier().String(), "task", task.String())
+			return m.nd.UpdateTask(task)
+		})
+	})
+}
 
-	task := tasks.NewTaskFromTask(*m.original, opts...)
-	slog.Debug("submitting rescheduled task", "identifier", task.Identifier().String(), "task", task.String())
-	if err := m.nd.UpdateTask(task); err != nil {
-		slog.Error("failed to update task", "error", err)
+// written handles the result of a submit, navigating back once every task has been rescheduled.
+func (m *Model) written(msg statusbar.WrittenEvent) (tea.Model, tea.Cmd) {
+	date := *m.submitting
+	m.submitting = nil
+	if len(msg.Errs) > 0 {
+		for _, err := range msg.Errs {
+			slog.Error("failed to update task", "error", err)
+		}
 
-		// TODO: We should probably show an error message to the user
+		// Stay on the view with only the failed tasks so they can be retried
+		verb := "reschedule task"
+		if len(m.originals) > 1 {
+			verb = fmt.Sprintf("reschedule %d of %d tasks", len(msg.Errs), len(m.originals))
+		}
+		m.footer.SetError(verb, msg.Errs[0].Err, m.keyMap.Retry)
+		m.failed = &date
+		m.originals = make([]tasks.Task, 0, len(msg.Errs))
+		for _, err := range msg.Errs {
+			m.originals = append(m.originals, err.Task)
+		}
+		m.footer.SetMode(statusbar.NewMode(modeText(len(m.originals)), statusbar.ActionEdit))
 		return m, nil
 	}
 
-	// If we've successfully created the task, we can navigate back to the previous view
+	// If we've successfully rescheduled the tasks, we can navigate back to the previous view
 	return m.ctx.Back(), nil
 }
+
+// Target is which of a task's dates are moved when it is rescheduled.
+type Target int
+
+const (
+	// Existing moves whichever of the due and scheduled dates are set, a task with neither is left as it is.
+	Existing Target = iota
+	DueOnly
+	ScheduledOnly
+	Both
+)
+
+var targetNames = map[Target]string{
+	Existing:      "the due and scheduled dates that are set",
+	DueOnly:       "the due date only",
+	ScheduledOnly: "the scheduled date only",
+	Both:          "both the due and scheduled dates",
+}
+
+// Reschedule moves whichever of the due and scheduled dates are set on the task to the given date.
+func Reschedule(task tasks.Task, date time.Time) tasks.Task {
+	return RescheduleDates(task, date, Existing)
+}
+
+// RescheduleDates moves the target dates of the task to the given date, adding them if they are not set.
+// Dates other than the target are left as they are.
+func RescheduleDates(task tasks.Task, date time.Time, target Target) tasks.Task {
+	due, scheduled := task.Due() != nil, task.Scheduled() != nil
+	switch target {
+	case DueOnly:
+		due, scheduled = true, false
+	case ScheduledOnly:
+		due, scheduled = false, true
+	case Both:
+		due, scheduled = true, true
+	}
+
+	opts := make([]tasks.TaskOption, 0)
+	if scheduled {
+		opts = append(opts, tasks.WithScheduled(date))
+	}
+	if due {
+		opts = append(opts, tasks.WithDue(date))
+	}
+	return tasks.NewTaskFromTask(task, opts...)
+}
@@ -14,7 +14,10 @@
 
 package taskreschedule
 
-import "github.com/charmbracelet/bubbles/v2/key"
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
 
 type KeyMap struct {
 	Today       key.Binding
@@ -28,6 +31,22 @@ type KeyMap struct {
 	InFortnight key.Binding
 	NextMonth   key.Binding
 	NextYear    key.Binding
+
+	OnlyDue       key.Binding
+	OnlyScheduled key.Binding
+	BothDates     key.Binding
+
+	TypeDate key.Binding
+	PickDate key.Binding
+	Submit   key.Binding
+	Retry    key.Binding
+
+	PickerLeft      key.Binding
+	PickerRight     key.Binding
+	PickerUp        key.Binding
+	PickerDown      key.Binding
+	PickerPrevMonth key.Binding
+	PickerNextMonth key.Binding
 }
 
 var DefaultKeyMap = KeyMap{
@@ -75,4 +94,105 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("y"),
 		key.WithHelp("y", "reschedule to 1st of next year"),
 	),
+	OnlyDue: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "only move the due date"),
+	),
+	OnlyScheduled: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "only move the scheduled date"),
+	),
+	BothDates: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "move both the due and scheduled dates"),
+	),
+	TypeDate: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "type a date e.g. 2024-12-01, fri or +3d"),
+	),
+	PickDate: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "pick a date from a calendar"),
+	),
+	Submit: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "reschedule to the typed or picked date"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "retry the failed tasks"),
+	),
+	PickerLeft: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("←/h", "previous day"),
+	),
+	PickerRight: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("→/l", "next day"),
+	),
+	PickerUp: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑/k", "previous week"),
+	),
+	PickerDown: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓/j", "next week"),
+	),
+	PickerPrevMonth: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous month"),
+	),
+	PickerNextMonth: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next month"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"Today":       &k.Today,
+		"Tommorrow":   &k.Tommorrow,
+		"InTwoDays":   &k.InTwoDays,
+		"InThreeDays": &k.InThreeDays,
+		"InFourDays":  &k.InFourDays,
+		"InFiveDays":  &k.InFiveDays,
+		"InSixDays":   &k.InSixDays,
+		"InSevenDays": &k.InSevenDays,
+		"InFortnight": &k.InFortnight,
+		"NextMonth":   &k.NextMonth,
+		"NextYear":    &k.NextYear,
+
+		"OnlyDue":       &k.OnlyDue,
+		"OnlyScheduled": &k.OnlyScheduled,
+		"BothDates":     &k.BothDates,
+
+		"TypeDate": &k.TypeDate,
+		"PickDate": &k.PickDate,
+		"Submit":   &k.Submit,
+		"Retry":    &k.Retry,
+
+		"PickerLeft":      &k.PickerLeft,
+		"PickerRight":     &k.PickerRight,
+		"PickerUp":        &k.PickerUp,
+		"PickerDown":      &k.PickerDown,
+		"PickerPrevMonth": &k.PickerPrevMonth,
+		"PickerNextMonth": &k.PickerNextMonth,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Today, k.Tommorrow, k.InSevenDays, k.TypeDate, k.PickDate, k.OnlyDue, k.OnlyScheduled}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Today, k.Tommorrow, k.InTwoDays, k.InThreeDays, k.InFourDays, k.InFiveDays},
+		{k.InSixDays, k.InSevenDays, k.InFortnight, k.NextMonth, k.NextYear},
+		{k.OnlyDue, k.OnlyScheduled, k.BothDates},
+		{k.TypeDate, k.PickDate, k.Submit, k.Retry},
+		{k.PickerLeft, k.PickerRight, k.PickerUp, k.PickerDown, k.PickerPrevMonth, k.PickerNextMonth},
+	}
 }
@@ -19,12 +19,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/help"
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/notedownorg/notedown/pkg/providers/tasks"
 	"github.com/notedownorg/task/pkg/components/statusbar"
 	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/dates"
 	"github.com/notedownorg/task/pkg/model"
 	"github.com/notedownorg/task/pkg/notedown"
 )
@@ -34,61 +36,193 @@ type Model struct {
 	ctx  *context.ProgramContext
 	nd   notedown.Client
 
-	original *tasks.Task
-	date     time.Time
+	originals []tasks.Task
+	date      time.Time
+	target    Target
+
+	// failed is the date of the last submit if it failed for any of the tasks, while set it can be retried
+	failed *time.Time
+
+	// submitting is the date the tasks are being rescheduled to while the writes run
+	submitting *time.Time
+
+	input  input
+	text   textinput.Model
+	picked time.Time
 
 	keyMap KeyMap
 
 	footer *statusbar.Model
 }
 
-func New(ctx *context.ProgramContext, nd notedown.Client, task *tasks.Task) *Model {
+// input is how the date is being chosen.
+type input int
+
+const (
+	choosing input = iota // one of the quick dates
+	typing                // a typed ISO or relative date
+	picking               // a date picked from the calendar
+)
+
+// New reschedules each of the given tasks to the same date.
+func New(ctx *context.ProgramContext, nd notedown.Client, ts ...tasks.Task) *Model {
 	date := time.Date(ctx.Now().Year(), ctx.Now().Month(), ctx.Now().Day(), 0, 0, 0, 0, ctx.Now().Location())
+
+	text := textinput.New()
+	text.Prompt = "󰁕 "
+	text.Placeholder = "2024-12-01, tomorrow, fri, +3d, eom..."
+
 	m := &Model{
-		ctx:      ctx,
-		nd:       nd,
-		original: task,
-		date:     date,
+		ctx:       ctx,
+		nd:        nd,
+		originals: ts,
+		date:      date,
+		text:      text,
+		picked:    date,
 
 		keyMap: DefaultKeyMap,
-		footer: statusbar.New(ctx, statusbar.NewMode("reschedule task", statusbar.ActionEdit), nd),
+		footer: statusbar.New(ctx, statusbar.NewMode(modeText(len(ts)), statusbar.ActionEdit), nd).SetHelp(DefaultKeyMap),
 	}
 	return m
 }
 
+// modeText describes the rescheduling in the statusbar mode.
+func modeText(n int) string {
+	if n == 1 {
+		return "reschedule task"
+	}
+	return fmt.Sprintf("reschedule %d tasks", n)
+}
+
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+// CapturingInput reports whether key presses are being typed into the date field.
+func (m *Model) CapturingInput() bool {
+	return m.input == typing
+}
+
 func (m *Model) Init() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	in := func(days int) func() (tea.Model, tea.Cmd) {
+		return func() (tea.Model, tea.Cmd) { return m.submit(m.in(days)) }
+	}
+	on := func(date time.Time) func() (tea.Model, tea.Cmd) {
+		return func() (tea.Model, tea.Cmd) { return m.submit(date) }
+	}
+	target := func(target Target) func() (tea.Model, tea.Cmd) {
+		return func() (tea.Model, tea.Cmd) { m.target = target; return nil, nil }
+	}
+	pick := func(days int, months int) func() (tea.Model, tea.Cmd) {
+		return func() (tea.Model, tea.Cmd) { m.picked = m.picked.AddDate(0, months, days); return nil, nil }
+	}
+	targets := []context.Action{
+		{Binding: m.keyMap.OnlyDue, Run: target(DueOnly)},
+		{Binding: m.keyMap.OnlyScheduled, Run: target(ScheduledOnly)},
+		{Binding: m.keyMap.BothDates, Run: target(Both)},
+	}
+	var retry []context.Action
+	if m.failed != nil {
+		retry = append(retry, context.Action{Binding: m.keyMap.Retry, Run: on(*m.failed)})
+	}
+
+	switch m.input {
+	case typing:
+		return append([]context.Action{
+			{Binding: m.keyMap.Submit, Run: m.submitTyped},
+		}, retry...)
+	case picking:
+		return append([]context.Action{
+			{Binding: m.keyMap.PickerLeft, Run: pick(-1, 0)},
+			{Binding: m.keyMap.PickerRight, Run: pick(1, 0)},
+			{Binding: m.keyMap.PickerUp, Run: pick(-7, 0)},
+			{Binding: m.keyMap.PickerDown, Run: pick(7, 0)},
+			{Binding: m.keyMap.PickerPrevMonth, Run: pick(0, -1)},
+			{Binding: m.keyMap.PickerNextMonth, Run: pick(0, 1)},
+			{Binding: m.keyMap.Submit, Run: func() (tea.Model, tea.Cmd) { return m.submit(m.picked) }},
+			{Binding: m.keyMap.TypeDate, Run: m.startTyping},
+		}, append(targets, retry...)...)
+	}
+
+	return append([]context.Action{
+		{Binding: m.keyMap.Today, Run: in(0)},
+		{Binding: m.keyMap.Tommorrow, Run: in(1)},
+		{Binding: m.keyMap.InTwoDays, Run: in(2)},
+		{Binding: m.keyMap.InThreeDays, Run: in(3)},
+		{Binding: m.keyMap.InFourDays, Run: in(4)},
+		{Binding: m.keyMap.InFiveDays, Run: in(5)},
+		{Binding: m.keyMap.InSixDays, Run: in(6)},
+		{Binding: m.keyMap.InSevenDays, Run: in(7)},
+		{Binding: m.keyMap.InFortnight, Run: on(m.fortnight())},
+		{Binding: m.keyMap.NextMonth, Run: on(m.nextMonth())},
+		{Binding: m.keyMap.NextYear, Run: on(m.nextYear())},
+		{Binding: m.keyMap.TypeDate, Run: m.startTyping},
+		{Binding: m.keyMap.PickDate, Run: func() (tea.Model, tea.Cmd) { m.input = picking; return nil, nil }},
+	}, append(targets, retry...)...)
+}
+
+// in returns the date the given number of days from today, counting only working days if configured.
+func (m *Model) in(days int) time.Time {
+	return m.ctx.Calendar().AddDays(m.date, days)
+}
+
+func (m *Model) fortnight() time.Time {
+	return m.ctx.Calendar().Forward(m.date.AddDate(0, 0, 14))
+}
+
+func (m *Model) nextMonth() time.Time {
+	return m.ctx.Calendar().Forward(time.Date(m.date.Year(), m.date.Month()+1, 1, 0, 0, 0, 0, m.date.Location()))
+}
+
+func (m *Model) nextYear() time.Time {
+	return m.ctx.Calendar().Forward(time.Date(m.date.Year()+1, 1, 1, 0, 0, 0, 0, m.date.Location()))
+}
+
+func (m *Model) startTyping() (tea.Model, tea.Cmd) {
+	m.input = typing
+	m.text.SetValue("")
+	return nil, m.text.Focus()
+}
+
+// typed returns the date in the text field, if it is one.
+func (m *Model) typed() (time.Time, bool) {
+	return dates.Parse(m.text.Value(), m.ctx.Now(), m.ctx.WeekStart(), m.ctx.Calendar())
+}
+
+func (m *Model) submitTyped() (tea.Model, tea.Cmd) {
+	date, ok := m.typed()
+	if !ok {
+		m.footer.SetMessage(fmt.Sprintf("%q is not a date", m.text.Value()), time.Now().Add(3*time.Second), m.ctx.Theme.Red)
+		return nil, nil
+	}
+	return m.submit(date)
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	// Handle view level key presses
 	switch msg := msg.(type) {
+	case statusbar.WrittenEvent:
+		// Failures are reported here rather than as a program notice so the retry key can be included
+		if msg.From(m.footer) {
+			return m.written(msg)
+		}
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, m.keyMap.Today):
-			return m.submit(m.date)
-		case key.Matches(msg, m.keyMap.Tommorrow):
-			return m.submit(m.date.AddDate(0, 0, 1))
-		case key.Matches(msg, m.keyMap.InTwoDays):
-			return m.submit(m.date.AddDate(0, 0, 2))
-		case key.Matches(msg, m.keyMap.InThreeDays):
-			return m.submit(m.date.AddDate(0, 0, 3))
-		case key.Matches(msg, m.keyMap.InFourDays):
-			return m.submit(m.date.AddDate(0, 0, 4))
-		case key.Matches(msg, m.keyMap.InFiveDays):
-			return m.submit(m.date.AddDate(0, 0, 5))
-		case key.Matches(msg, m.keyMap.InSixDays):
-			return m.submit(m.date.AddDate(0, 0, 6))
-		case key.Matches(msg, m.keyMap.InSevenDays):
-			return m.submit(m.date.AddDate(0, 0, 7))
-		case key.Matches(msg, m.keyMap.InFortnight):
-			return m.submit(m.date.AddDate(0, 0, 14))
-		case key.Matches(msg, m.keyMap.NextMonth):
-			return m.submit(time.Date(m.date.Year(), m.date.Month()+1, 1, 0, 0, 0, 0, m.date.Location()))
-		case key.Matches(msg, m.keyMap.NextYear):
-			return m.submit(time.Date(m.date.Year()+1, 1, 1, 0, 0, 0, 0, m.date.Location()))
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		} else if m.input == typing {
+			m.text, cmd = m.text.Update(msg)
 		}
 	}
 
@@ -117,23 +251,45 @@ func (m *Model) View() string {
 		)
 	}
 
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.TextFaint)
+	var body string
+	switch m.input {
+	case typing:
+		preview := faint.Render("type an ISO or relative date")
+		if m.text.Value() != "" {
+			if date, ok := m.typed(); ok {
+				preview = lipgloss.NewStyle().Foreground(m.ctx.Theme.Green).Render(date.Format("Monday 2006-01-02"))
+			} else {
+				preview = lipgloss.NewStyle().Foreground(m.ctx.Theme.Red).Render("not a date")
+			}
+		}
+		body = lipgloss.JoinVertical(lipgloss.Top, m.text.View(), "", preview)
+	case picking:
+		body = m.pickerView()
+	default:
+		body = lipgloss.JoinVertical(lipgloss.Top,
+			optRender(m.date, "0", "today"),
+			optRender(m.in(1), "1", "tomorrow"),
+			optRender(m.in(2), "2", "in two days"),
+			optRender(m.in(3), "3", "in three days"),
+			optRender(m.in(4), "4", "in four days"),
+			optRender(m.in(5), "5", "in five days"),
+			optRender(m.in(6), "6", "in six days"),
+			optRender(m.in(7), "7", "in seven days"),
+			optRender(m.fortnight(), "f", "in a fortnight"),
+			optRender(m.nextMonth(), "m", "next month"),
+			optRender(m.nextYear(), "y", "next year"),
+			"",
+			m.base.NewStyle().Render("t 󰁕 type a date ")+faint.Render("[iso or relative]"),
+			m.base.NewStyle().Render("c 󰁕 pick a date ")+faint.Render("[calendar]"),
+		)
+	}
+
+	target := faint.Render("moving " + targetNames[m.target] + " (d/s/b)")
+
 	top := lipgloss.NewStyle().
 		Margin(1, 3).
-		Render(
-			lipgloss.JoinVertical(lipgloss.Top,
-				optRender(m.date, "0", "today"),
-				optRender(m.date.AddDate(0, 0, 1), "1", "tomorrow"),
-				optRender(m.date.AddDate(0, 0, 2), "2", "in two days"),
-				optRender(m.date.AddDate(0, 0, 3), "3", "in three days"),
-				optRender(m.date.AddDate(0, 0, 4), "4", "in four days"),
-				optRender(m.date.AddDate(0, 0, 5), "5", "in five days"),
-				optRender(m.date.AddDate(0, 0, 6), "6", "in six days"),
-				optRender(m.date.AddDate(0, 0, 7), "7", "in seven days"),
-				optRender(m.date.AddDate(0, 0, 14), "f", "in a fortnight"),
-				optRender(time.Date(m.date.Year(), m.date.Month()+1, 1, 0, 0, 0, 0, m.date.Location()), "m", "next month"),
-				optRender(time.Date(m.date.Year()+1, 1, 1, 0, 0, 0, 0, m.date.Location()), "y", "next year"),
-			),
-		)
+		Render(lipgloss.JoinVertical(lipgloss.Top, body, "", target))
 
 	border := lipgloss.RoundedBorder()
 	var b strings.Builder
@@ -0,0 +1,63 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskreschedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerView renders the month of the picked date with the picked day highlighted.
+func (m *Model) pickerView() string {
+	first := time.Date(m.picked.Year(), m.picked.Month(), 1, 0, 0, 0, 0, m.picked.Location())
+	start := first.AddDate(0, 0, -((int(first.Weekday()) - int(m.ctx.WeekStart()) + 7) % 7))
+	today := m.date
+
+	cell := lipgloss.NewStyle().Width(4).Align(lipgloss.Right)
+
+	names := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((int(m.ctx.WeekStart()) + i) % 7)
+		names = append(names, cell.Foreground(m.ctx.Theme.TextFaint).Render(day.String()[:2]+" "))
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().Width(28).Align(lipgloss.Center).Render(fmt.Sprintf("← %s →", first.Format("January 2006"))),
+		strings.Join(names, ""),
+	}
+	for day := start; day.Month() == first.Month() || day.Before(first); day = day.AddDate(0, 0, 7) {
+		cells := make([]string, 0, 7)
+		for i := 0; i < 7; i++ {
+			date := day.AddDate(0, 0, i)
+			style := cell.Foreground(m.ctx.Theme.Text)
+			switch {
+			case date.Equal(m.picked):
+				style = style.Foreground(m.ctx.Theme.TextCursor).Background(m.ctx.Theme.Yellow).Bold(true)
+			case date.Equal(today):
+				style = style.Foreground(m.ctx.Theme.Green)
+			case date.Month() != first.Month():
+				style = style.Foreground(m.ctx.Theme.TextFaint)
+			case !m.ctx.Calendar().IsWorkingDay(date):
+				style = style.Foreground(m.ctx.Theme.RedSoft)
+			}
+			cells = append(cells, style.Render(fmt.Sprintf("%d ", date.Day())))
+		}
+		rows = append(rows, strings.Join(cells, ""))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weekview
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/views/taskeditor"
+	"github.com/notedownorg/task/pkg/views/taskreschedule"
+)
+
+// Actions are dispatched from both key presses and the command palette.
+func (m *Model) Actions() []context.Action {
+	return []context.Action{
+		{Binding: m.keyMap.CursorUp, Run: func() (tea.Model, tea.Cmd) { m.moveRow(-1); return nil, nil }},
+		{Binding: m.keyMap.CursorDown, Run: func() (tea.Model, tea.Cmd) { m.moveRow(1); return nil, nil }},
+		{Binding: m.keyMap.PrevDay, Run: func() (tea.Model, tea.Cmd) { m.moveDay(-1); return nil, nil }},
+		{Binding: m.keyMap.NextDay, Run: func() (tea.Model, tea.Cmd) { m.moveDay(1); return nil, nil }},
+		{Binding: m.keyMap.PrevWeek, Run: func() (tea.Model, tea.Cmd) { m.moveWeek(-1); return nil, nil }},
+		{Binding: m.keyMap.NextWeek, Run: func() (tea.Model, tea.Cmd) { m.moveWeek(1); return nil, nil }},
+		{Binding: m.keyMap.ResetDate, Run: func() (tea.Model, tea.Cmd) { m.resetDate(); return nil, nil }},
+		{Binding: m.keyMap.EditTask, Run: m.editTask},
+		{Binding: m.keyMap.MoveTask, Run: m.moveTask},
+	}
+}
+
+func (m *Model) moveRow(n int) {
+	if m.moving != nil {
+		return
+	}
+	m.row += n
+	m.clampCursor()
+}
+
+// moveDay moves the cursor across the days, continuing into the previous or next week at either end.
+func (m *Model) moveDay(n int) {
+	m.day += n
+	switch {
+	case m.day < 0:
+		m.day += days
+		m.moveWeek(-1)
+	case m.day >= days:
+		m.day -= days
+		m.moveWeek(1)
+	}
+	m.clampCursor()
+}
+
+func (m *Model) moveWeek(n int) {
+	m.start = m.start.AddDate(0, 0, days*n)
+	m.updateTasks()
+}
+
+func (m *Model) resetDate() {
+	today := m.today()
+	m.start = weekStart(today, m.ctx.WeekStart())
+	m.day = int(today.Sub(m.start) / (24 * time.Hour))
+	m.row = 0
+	m.updateTasks()
+}
+
+func (m *Model) editTask() (tea.Model, tea.Cmd) {
+	if m.moving != nil {
+		return nil, nil
+	}
+	if selected := m.selectedTask(); selected != nil {
+		return m.ctx.Navigate(taskeditor.New(
+			m.ctx,
+			m.nd,
+			taskeditor.WithEdit(*selected, m.columns[m.day].date),
+		))
+	}
+	return nil, nil
+}
+
+// moveTask picks up the selected task or, if one has already been picked up, drops it on the day of the cursor.
+func (m *Model) moveTask() (tea.Model, tea.Cmd) {
+	if m.moving == nil {
+		if m.row >= len(m.columns[m.day].open) {
+			m.footer.SetMessage("only open tasks can be moved", time.Now().Add(5*time.Second), m.ctx.Theme.Yellow)
+			return nil, nil
+		}
+		task := m.columns[m.day].open[m.row]
+		m.moving, m.movingFrom = &task, m.columns[m.day].date
+		m.footer.SetMessage(fmt.Sprintf("moving %q, pick a day and press %s to drop it", task.Name(), m.keyMap.MoveTask.Help().Key), time.Now().Add(time.Hour), m.ctx.Theme.Yellow)
+		return nil, nil
+	}
+
+	task, date := *m.moving, m.columns[m.day].date
+	m.moving = nil
+	m.footer.SetMessage("", time.Time{}, m.ctx.Theme.Text)
+	if date.Equal(m.movingFrom) {
+		return nil, nil
+	}
+
+	// Rewrites the dates the same way as the reschedule view
+	return nil, m.footer.Write("move", 1, func() []notedown.TaskError {
+		return notedown.WriteTasks(m.nd, []tasks.Task{task}, func(t tasks.Task) error {
+			return m.nd.UpdateTask(taskreschedule.Reschedule(t, date))
+		})
+	})
+}
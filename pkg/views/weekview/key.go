@@ -0,0 +1,102 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weekview
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/notedownorg/task/pkg/context"
+)
+
+type KeyMap struct {
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	PrevDay    key.Binding
+	NextDay    key.Binding
+
+	PrevWeek  key.Binding
+	NextWeek  key.Binding
+	ResetDate key.Binding
+
+	EditTask key.Binding
+	MoveTask key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	CursorUp: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑/k", "move cursor up"),
+	),
+	CursorDown: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓/j", "move cursor down"),
+	),
+	PrevDay: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("←/h", "previous day"),
+	),
+	NextDay: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("→/l", "next day"),
+	),
+	PrevWeek: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous week"),
+	),
+	NextWeek: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next week"),
+	),
+	ResetDate: key.NewBinding(
+		key.WithKeys("home", "0"),
+		key.WithHelp("home/0", "go to today"),
+	),
+	EditTask: key.NewBinding(
+		key.WithKeys("e", "enter"),
+		key.WithHelp("e/enter", "edit the selected task"),
+	),
+	MoveTask: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "pick up or drop the selected task to move it to another day"),
+	),
+}
+
+// Bindings exposes each action by name so it can be remapped from the config file.
+func (k *KeyMap) Bindings() context.Bindings {
+	return context.Bindings{
+		"CursorUp":   &k.CursorUp,
+		"CursorDown": &k.CursorDown,
+		"PrevDay":    &k.PrevDay,
+		"NextDay":    &k.NextDay,
+		"PrevWeek":   &k.PrevWeek,
+		"NextWeek":   &k.NextWeek,
+		"ResetDate":  &k.ResetDate,
+		"EditTask":   &k.EditTask,
+		"MoveTask":   &k.MoveTask,
+	}
+}
+
+// ShortHelp implements help.KeyMap, it is used for the hint row in the status bar.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevDay, k.NextDay, k.PrevWeek, k.NextWeek, k.MoveTask, k.EditTask}
+}
+
+// FullHelp implements help.KeyMap, it is used for the help overlay.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.CursorUp, k.CursorDown, k.PrevDay, k.NextDay},
+		{k.PrevWeek, k.NextWeek, k.ResetDate},
+		{k.EditTask, k.MoveTask},
+	}
+}
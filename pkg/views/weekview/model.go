@@ -0,0 +1,138 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weekview
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/help"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/components/statusbar"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/listeners"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+const (
+	view = "week"
+	days = 7
+)
+
+func HandleNew(nd notedown.Client) context.GlobalKeyHandler {
+	return context.GlobalKeyHandler{
+		Binding: context.DefaultGlobalKeyMap.Week,
+		Handle: func(ctx *context.ProgramContext) (tea.Model, tea.Cmd) {
+			return ctx.Navigate(New(ctx, nd))
+		},
+	}
+}
+
+func New(ctx *context.ProgramContext, nd notedown.Client) *Model {
+	m := &Model{
+		ctx:    ctx,
+		nd:     nd,
+		keyMap: DefaultKeyMap,
+		footer: statusbar.New(ctx, statusbar.NewMode(view, statusbar.ActionNeutral), nd).SetHelp(DefaultKeyMap),
+	}
+	m.resetDate()
+	return m
+}
+
+type Model struct {
+	ctx *context.ProgramContext
+	nd  notedown.Client
+
+	keyMap KeyMap
+
+	start   time.Time // first day of the week shown
+	columns [days]column
+
+	day int // column of the cursor
+	row int // index of the cursor within the column
+
+	// moving is the task picked up to be moved to another day, it is shown on the day of the cursor until dropped
+	moving     *tasks.Task
+	movingFrom time.Time
+
+	footer *statusbar.Model
+}
+
+// Help returns the key map shown in the help overlay.
+func (m *Model) Help() help.KeyMap {
+	return m.keyMap
+}
+
+func (m *Model) Init() (tea.Model, tea.Cmd) {
+	_, cmd := m.ctx.Init()
+	return m, cmd
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if action, ok := context.MatchAction(msg, m.Actions()); ok {
+			model, command := action.Run()
+			if model != nil { // if model is not nil we're navigating to a new view
+				return model, command
+			}
+			cmd = command
+		}
+	}
+
+	// If the task client has emitted an event, refresh the tasks
+	if _, ok := msg.(listeners.TaskEvent); ok {
+		m.updateTasks()
+	}
+
+	// If we're being navigated back to, refresh the tasks
+	if _, ok := msg.(context.NavigationEvent); ok {
+		m.updateTasks()
+	}
+
+	// Handle program level key presses and events
+	model, command := m.ctx.Update(msg)
+	if model != nil { // if model is not nil we're navigating to a new view
+		return model, tea.Batch(command, cmd)
+	}
+	cmd = tea.Batch(cmd, command)
+	return m, cmd
+}
+
+// today is the current date at midnight UTC as that is how task dates are stored.
+func (m *Model) today() time.Time {
+	now := m.ctx.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart returns the first day of the week containing the date.
+func weekStart(date time.Time, first time.Weekday) time.Time {
+	offset := (int(date.Weekday()) - int(first) + days) % days
+	return date.AddDate(0, 0, -offset)
+}
+
+func (m *Model) selectedTask() *tasks.Task {
+	items := m.columns[m.day].items()
+	if m.row < 0 || m.row >= len(items) {
+		return nil
+	}
+	return &items[m.row]
+}
+
+// clampCursor keeps the cursor on an item of the current day, or the first row if there are none.
+func (m *Model) clampCursor() {
+	m.row = max(min(m.row, len(m.columns[m.day].items())-1), 0)
+}
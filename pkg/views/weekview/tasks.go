@@ -0,0 +1,84 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weekview
+
+import (
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/notedown"
+	"github.com/notedownorg/task/pkg/styling/tasklists"
+	"github.com/notedownorg/task/pkg/views/agenda"
+)
+
+// column holds the tasks for a single day of the week.
+type column struct {
+	date time.Time
+	open []tasks.Task
+	done []tasks.Task
+}
+
+// items are the open tasks followed by the completed tasks, in the order they are displayed.
+func (c column) items() []tasks.Task {
+	res := make([]tasks.Task, 0, len(c.open)+len(c.done))
+	return append(append(res, c.open...), c.done...)
+}
+
+func (m *Model) updateTasks() {
+	m.columns = columns(m.nd, m.start, m.today())
+	m.clampCursor()
+}
+
+// columns places each open task on the first day it would appear in the agenda, or today if that day has passed, so
+// overdue tasks gather on today rather than being repeated on every day after they were due.
+func columns(nd notedown.Client, start time.Time, today time.Time) [days]column {
+	var res [days]column
+	for i := range res {
+		date := start.AddDate(0, 0, i)
+		res[i] = column{date: date, done: agenda.Done(nd, date)}
+		switch {
+		case date.Equal(today):
+			res[i].open = agenda.Due(nd, date)
+		case date.After(today):
+			res[i].open = newlyDue(nd, date)
+		}
+	}
+	return res
+}
+
+// newlyDue returns the tasks that appear in the agenda on the date but not the day before.
+func newlyDue(nd notedown.Client, date time.Time) []tasks.Task {
+	before := make(map[string]struct{})
+	for _, t := range agenda.Due(nd, date.AddDate(0, 0, -1)) {
+		before[tasklists.Identity(t)] = struct{}{}
+	}
+	res := make([]tasks.Task, 0)
+	for _, t := range agenda.Due(nd, date) {
+		if _, ok := before[tasklists.Identity(t)]; !ok {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// overdue reports whether the earliest of the task's due and scheduled dates is before today.
+func overdue(task tasks.Task, today time.Time) bool {
+	for _, date := range []*time.Time{task.Due(), task.Scheduled()} {
+		if date != nil && time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Before(today) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weekview
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/internal/testenv"
+	"github.com/notedownorg/task/pkg/context"
+	"github.com/notedownorg/task/pkg/notedown"
+)
+
+// The week of Wednesday 2024-06-05 starting on Sunday 2024-06-02.
+const week = `# Week
+- [ ] Overdue due:2024-05-30
+- [ ] Due on Sunday due:2024-06-02
+- [/] Due today due:2024-06-05
+- [ ] Due on Friday due:2024-06-07
+- [ ] Scheduled Thursday due:2024-06-08 scheduled:2024-06-06
+- [b] Due on Saturday due:2024-06-08
+- [ ] Due next week due:2024-06-09
+- [ ] Undated
+- [x] Done on Monday completed:2024-06-03
+- [x] Done today due:2024-06-04 completed:2024-06-05
+- [a] Abandoned due:2024-06-06
+`
+
+func TestColumns(t *testing.T) {
+	nd, _ := testenv.Workspace(t, notedown.NewClient, map[string]string{"week.md": week})
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC)
+	m := testenv.View(now, func(ctx *context.ProgramContext) *Model { return New(ctx, nd) }, context.WithWeekStart(time.Sunday))
+
+	if want := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC); !m.start.Equal(want) {
+		t.Fatalf("week starts %s, want %s", m.start.Format(time.DateOnly), want.Format(time.DateOnly))
+	}
+	if m.day != 3 {
+		t.Errorf("cursor is on day %d, want today (3)", m.day)
+	}
+
+	// Tasks of the same status and priority are in no particular order
+	names := func(ts []tasks.Task) []string {
+		res := make([]string, 0, len(ts))
+		for _, t := range ts {
+			res = append(res, t.Name())
+		}
+		sort.Strings(res)
+		return res
+	}
+	want := [days]struct{ open, done []string }{
+		{open: []string{}, done: []string{}},
+		{open: []string{}, done: []string{"Done on Monday"}},
+		{open: []string{}, done: []string{}},
+		{open: []string{"Due on Sunday", "Due today", "Overdue"}, done: []string{"Done today"}},
+		{open: []string{"Scheduled Thursday"}, done: []string{}},
+		{open: []string{"Due on Friday"}, done: []string{}},
+		{open: []string{"Due on Saturday"}, done: []string{}},
+	}
+	for i, c := range m.columns {
+		if got := c.date.Weekday(); got != time.Weekday(i) {
+			t.Errorf("column %d is a %s", i, got)
+		}
+		if got := names(c.open); !reflect.DeepEqual(got, want[i].open) {
+			t.Errorf("%s open = %q, want %q", c.date.Weekday(), got, want[i].open)
+		}
+		if got := names(c.done); !reflect.DeepEqual(got, want[i].done) {
+			t.Errorf("%s done = %q, want %q", c.date.Weekday(), got, want[i].done)
+		}
+	}
+
+	// Moving to the next week nothing is overdue so each task is on its own day
+	m.moveWeek(1)
+	if got := names(m.columns[0].open); !reflect.DeepEqual(got, []string{"Due next week"}) {
+		t.Errorf("next Sunday open = %q", got)
+	}
+	for _, c := range m.columns[1:] {
+		if len(c.open) > 0 {
+			t.Errorf("%s open = %q, want none", c.date.Format(time.DateOnly), names(c.open))
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	wednesday := time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		date  time.Time
+		first time.Weekday
+		want  time.Time
+	}{
+		{date: wednesday, first: time.Sunday, want: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{date: wednesday, first: time.Monday, want: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{date: wednesday, first: time.Wednesday, want: wednesday},
+		{date: wednesday, first: time.Thursday, want: time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)},
+		{date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), first: time.Monday, want: time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.Format(time.DateOnly)+" "+tt.first.String(), func(t *testing.T) {
+			if got := weekStart(tt.date, tt.first); !got.Equal(tt.want) {
+				t.Errorf("weekStart() = %s, want %s", got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+			}
+		})
+	}
+}
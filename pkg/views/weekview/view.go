@@ -0,0 +1,144 @@
+// Copyright 2024 Notedown Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weekview
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/notedownorg/notedown/pkg/providers/tasks"
+	"github.com/notedownorg/task/pkg/styling/colors"
+	"github.com/notedownorg/task/pkg/styling/icons"
+	"github.com/notedownorg/task/pkg/styling/tasklists"
+)
+
+var (
+	s = lipgloss.NewStyle
+	h = lipgloss.Height
+)
+
+func (m *Model) View() string {
+	horizontalPadding := 2
+	verticalPadding := 1
+	gap := 1
+
+	end := m.start.AddDate(0, 0, days-1)
+	header := fmt.Sprintf("← %s – %s →", m.start.Format("January 2"), end.Format("January 2, 2006"))
+
+	footer := m.footer.
+		Width(m.ctx.ScreenWidth - horizontalPadding*2).
+		View()
+
+	width := (m.ctx.ScreenWidth - horizontalPadding*2 - gap*(days-1)) / days
+	height := m.ctx.ScreenHeight - h(footer) - h(header) - verticalPadding*2 - 2 // -2 for the gaps
+
+	cols := make([]string, 0, days*2)
+	for i := range m.columns {
+		if i > 0 {
+			cols = append(cols, strings.Repeat(" ", gap))
+		}
+		cols = append(cols, m.renderColumn(i, width, height))
+	}
+
+	main := lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+	panel := lipgloss.JoinVertical(lipgloss.Top, header, "", main, "", footer)
+
+	return s().Padding(verticalPadding, horizontalPadding).Render(panel)
+}
+
+// renderColumn renders the day's header followed by as many of its tasks as fit, scrolled to keep the cursor visible.
+func (m *Model) renderColumn(i int, width int, height int) string {
+	c := m.columns[i]
+	today := m.today()
+
+	headerStyle := s().Width(width).Align(lipgloss.Center).Foreground(m.ctx.Theme.Text).Background(m.ctx.Theme.Panel)
+	if c.date.Equal(today) {
+		headerStyle = headerStyle.Foreground(m.ctx.Theme.TextCursor).Background(m.ctx.Theme.Blue).Bold(true)
+	}
+	if i == m.day {
+		headerStyle = headerStyle.Underline(true)
+	}
+	lines := []string{headerStyle.Render(c.date.Format("Mon 2")), ""}
+
+	items := c.items()
+	cursor := -1
+	if i == m.day {
+		cursor = m.row
+	}
+	if m.moving != nil {
+		items = without(items, *m.moving)
+		cursor = -1
+		if i == m.day {
+			items = append([]tasks.Task{*m.moving}, items...)
+		}
+	}
+
+	rows := max(height-len(lines), 1)
+	offset := 0
+	if cursor >= rows {
+		offset = cursor - rows + 1
+	}
+	for j := offset; j < len(items) && j < offset+rows; j++ {
+		if j == offset+rows-1 && j < len(items)-1 && j != cursor {
+			lines = append(lines, s().Foreground(m.ctx.Theme.TextFaint).Render(fmt.Sprintf("+%d more", len(items)-j)))
+			break
+		}
+		moving := m.moving != nil && i == m.day && j == 0
+		lines = append(lines, m.renderTask(items[j], width, j == cursor, moving))
+	}
+
+	return s().Width(width).Height(height).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m *Model) renderTask(task tasks.Task, width int, selected bool, moving bool) string {
+	theme := m.ctx.Theme
+	bg, fg := m.taskColors(task, selected)
+	switch {
+	case moving:
+		bg, fg = theme.Yellow, theme.TextCursor
+	case !selected && task.Status() != tasks.Done && overdue(task, m.today()):
+		fg = theme.Red
+	}
+
+	icon := icons.Task(task.Status())
+	name := runewidth.Truncate(task.Name(), width-lipgloss.Width(icon)-3, "…") // -3 for the padding and gap
+	return s().Width(width).Padding(0, 1).Background(bg).Foreground(fg).Render(icon + " " + name)
+}
+
+func (m *Model) taskColors(task tasks.Task, selected bool) (bg, fg lipgloss.Color) {
+	colorsFor := colors.Task
+	if selected {
+		colorsFor = colors.TaskSelected
+	}
+	bg, fg, err := colorsFor(m.ctx.Theme, task.Status())
+	if err != nil {
+		slog.Warn("unexpected task status", "status", task.Status())
+	}
+	return bg, fg
+}
+
+// without returns the items other than the given task.
+func without(items []tasks.Task, task tasks.Task) []tasks.Task {
+	res := make([]tasks.Task, 0, len(items))
+	for _, item := range items {
+		if tasklists.Identity(item) != tasklists.Identity(task) {
+			res = append(res, item)
+		}
+	}
+	return res
+}